@@ -29,6 +29,53 @@ fn bench_add_numbers(c: &mut Criterion) {
     teardown();
 }
 
+fn bench_add_numbers_batch(c: &mut Criterion) {
+    use rust_go_ffi::ffi::{AddNumbers, AddNumbersBatch};
+
+    let mut group = c.benchmark_group("add_numbers_batch");
+
+    for size in [1, 100, 10_000].iter() {
+        let mut a: Vec<i64> = (0..*size as i64).collect();
+        let mut b = a.clone();
+        let mut out = vec![0i64; *size];
+
+        group.bench_with_input(BenchmarkId::new("per_element", size), size, |bench, _| {
+            bench.iter(|| {
+                for i in 0..a.len() {
+                    out[i] = unsafe { AddNumbers(black_box(a[i]), black_box(b[i])) };
+                }
+            });
+        });
+        group.bench_with_input(BenchmarkId::new("batched", size), size, |bench, &size| {
+            bench.iter(|| unsafe {
+                AddNumbersBatch(
+                    a.as_mut_ptr(),
+                    b.as_mut_ptr(),
+                    out.as_mut_ptr(),
+                    black_box(size),
+                );
+            });
+        });
+    }
+
+    group.finish();
+}
+
+fn bench_crossing(c: &mut Criterion) {
+    use rust_go_ffi::ffi::{BusyWork, NoOp};
+
+    let mut group = c.benchmark_group("crossing");
+    group.bench_function("noop", |b| b.iter(|| unsafe { NoOp() }));
+    for iterations in [1i64, 1000].iter() {
+        group.bench_with_input(
+            BenchmarkId::new("busy_work", iterations),
+            iterations,
+            |b, &iterations| b.iter(|| unsafe { BusyWork(black_box(iterations)) }),
+        );
+    }
+    group.finish();
+}
+
 fn bench_initialization(c: &mut Criterion) {
     let mut group = c.benchmark_group("initialization");
     group.measurement_time(Duration::from_secs(5));
@@ -49,6 +96,6 @@ criterion_group! {
         .with_plots() // Enable plot generation
         .sample_size(50)
         .measurement_time(Duration::from_secs(30));
-    targets = bench_add_numbers, bench_initialization
+    targets = bench_add_numbers, bench_add_numbers_batch, bench_crossing, bench_initialization
 }
 criterion_main!(benches);
@@ -95,7 +95,8 @@ def go_build():
     """
     logging.info("Building Go shared library...")
     try:
-        # Run in FFI_DIR so that output file is just "go_lib.dll"
+        # Run in FFI_DIR so that output file is just "go_lib.dll".
+        # Build the whole package since the exports span several files.
         run_command(
             [
                 "go",
@@ -103,7 +104,7 @@ def go_build():
                 "-buildmode=c-shared",
                 "-o",
                 f"{EXPORT_NAME}.dll",
-                f"{EXPORT_NAME}.go",
+                ".",
             ],
             cwd=str(FFI_DIR),
         )
@@ -1,4 +1,5 @@
 use std::env;
+use std::fs;
 use std::path::PathBuf;
 use std::process::Command;
 
@@ -8,7 +9,7 @@ const INPUT_HEADER: &str = "go_lib/go_lib.h";
 fn main() {
     // Instruct Cargo when to re-run this build script.
     println!("cargo:rerun-if-changed=build.py");
-    println!("cargo:rerun-if-changed=go_lib/go_lib.go");
+    rerun_if_go_sources_changed();
     println!("cargo:rerun-if-changed=go_lib/go_lib.h");
     println!("cargo:rerun-if-changed=build.rs");
 
@@ -45,3 +46,15 @@ fn main() {
         .write_to_file(out_path.join("bindings.rs"))
         .expect("Couldn't write bindings!");
 }
+
+/// Emits a rerun-if-changed line for every Go source file in the library,
+/// since the exports are spread over several files.
+fn rerun_if_go_sources_changed() {
+    let entries = fs::read_dir(LIBRARY_PATH).expect("Failed to read Go library directory");
+    for entry in entries.flatten() {
+        let path = entry.path();
+        if path.extension().map_or(false, |ext| ext == "go") {
+            println!("cargo:rerun-if-changed={}", path.display());
+        }
+    }
+}
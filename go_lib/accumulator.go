@@ -0,0 +1,75 @@
+package main
+
+// #include <stdint.h>
+import "C"
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Stateful objects are handed to the host as integer handles rather than Go
+// pointers, so the garbage collector keeps full ownership of them. Handle 0
+// is never issued.
+
+type accumulator struct {
+	total atomic.Int64
+}
+
+var (
+	accumulators      sync.Map // uintptr -> *accumulator
+	nextAccumulatorID atomic.Uintptr
+)
+
+func lookupAccumulator(h C.uintptr_t) (*accumulator, error) {
+	if v, ok := accumulators.Load(uintptr(h)); ok {
+		return v.(*accumulator), nil
+	}
+	return nil, newError(ErrInvalidArg, "invalid accumulator handle %d", h)
+}
+
+// NewAccumulator creates an accumulator with a zero total and returns its
+// handle. Release it with FreeAccumulator.
+//
+//export NewAccumulator
+func NewAccumulator() (h C.uintptr_t) {
+	defer trackCall("NewAccumulator", time.Now())
+	recoverToError(func() error {
+		id := nextAccumulatorID.Add(1)
+		accumulators.Store(id, &accumulator{})
+		h = C.uintptr_t(id)
+		return nil
+	})
+	return h
+}
+
+// AccumulatorAdd adds v to the accumulator and returns the running total. An
+// invalid or freed handle returns 0 and records ErrInvalidArg.
+//
+//export AccumulatorAdd
+func AccumulatorAdd(h C.uintptr_t, v C.longlong) (total C.longlong) {
+	defer trackCall("AccumulatorAdd", time.Now())
+	recoverToError(func() error {
+		acc, err := lookupAccumulator(h)
+		if err != nil {
+			return err
+		}
+		total = C.longlong(acc.total.Add(int64(v)))
+		return nil
+	})
+	return total
+}
+
+// FreeAccumulator releases the accumulator. It returns ErrInvalidArg if the
+// handle is unknown or was already freed.
+//
+//export FreeAccumulator
+func FreeAccumulator(h C.uintptr_t) C.int {
+	defer trackCall("FreeAccumulator", time.Now())
+	return recoverToError(func() error {
+		if _, ok := accumulators.LoadAndDelete(uintptr(h)); !ok {
+			return newError(ErrInvalidArg, "invalid accumulator handle %d", h)
+		}
+		return nil
+	})
+}
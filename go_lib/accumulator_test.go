@@ -0,0 +1,44 @@
+package main
+
+import "testing"
+
+func TestAccumulatorsAreIndependent(t *testing.T) {
+	a, b := NewAccumulator(), NewAccumulator()
+	if a == 0 || b == 0 || a == b {
+		t.Fatalf("handles = %d, %d; want two distinct nonzero handles", a, b)
+	}
+	defer FreeAccumulator(a)
+	defer FreeAccumulator(b)
+
+	AccumulatorAdd(a, 10)
+	AccumulatorAdd(b, 100)
+	AccumulatorAdd(a, 5)
+
+	if got := AccumulatorAdd(a, 0); got != 15 {
+		t.Errorf("accumulator a total = %d, want 15", got)
+	}
+	if got := AccumulatorAdd(b, -1); got != 99 {
+		t.Errorf("accumulator b total = %d, want 99", got)
+	}
+}
+
+func TestAccumulatorInvalidHandle(t *testing.T) {
+	lockThread(t)
+
+	h := NewAccumulator()
+	if code := FreeAccumulator(h); code != ErrOK {
+		t.Fatalf("FreeAccumulator = %d, want %d", code, ErrOK)
+	}
+	if code := FreeAccumulator(h); code != ErrInvalidArg {
+		t.Errorf("double FreeAccumulator = %d, want %d", code, ErrInvalidArg)
+	}
+	if got := AccumulatorAdd(h, 1); got != 0 {
+		t.Errorf("AccumulatorAdd on freed handle = %d, want 0", got)
+	}
+	if msg := lastErrorString(); msg == "" {
+		t.Error("AccumulatorAdd on freed handle should set the last error")
+	}
+	if code := FreeAccumulator(0); code != ErrInvalidArg {
+		t.Errorf("FreeAccumulator(0) = %d, want %d", code, ErrInvalidArg)
+	}
+}
@@ -0,0 +1,16 @@
+package main
+
+// #include <stdlib.h>
+import "C"
+
+// Every C allocation whose ownership passes to the caller calls trackAlloc,
+// and every export that releases one calls trackFree. Builds with the
+// allocdebug tag count the live allocations (see alloc_track.go); otherwise
+// both are no-ops.
+
+// allocString is C.CString for strings returned to the caller, who releases
+// them with FreeCString.
+func allocString(s string) *C.char {
+	trackAlloc()
+	return C.CString(s)
+}
@@ -0,0 +1,7 @@
+//go:build !allocdebug
+
+package main
+
+func trackAlloc() {}
+
+func trackFree() {}
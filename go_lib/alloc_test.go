@@ -0,0 +1,156 @@
+//go:build allocdebug
+
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Run with: go test -tags allocdebug -run Alloc
+
+// allocatingExports calls every export that hands C memory to the caller and
+// frees the result the way a well-behaved caller would.
+var allocatingExports = map[string]func(){
+	"GetDLLVersionString": func() { FreeCString(GetDLLVersionString()) },
+	"ConcatStrings": func() {
+		a := cString("a")
+		FreeCString(ConcatStrings(a, nil))
+		FreeCString(a)
+	},
+	"JoinStrings": func() {
+		arr := cStringArray([]string{"x", "y"})
+		sep := cString(",")
+		FreeCString(JoinStrings(arr, 2, sep))
+		FreeCString(sep)
+		freeCStringArray(arr, 2)
+	},
+	"GetLastError": func() {
+		TriggerPanic(nil)
+		FreeCString(GetLastError())
+	},
+	"ProcessJSON": func() {
+		valid, invalid := cString(`{"a":1,"b":2}`), cString(`{`)
+		FreeCString(ProcessJSON(valid))
+		FreeCString(ProcessJSON(invalid))
+		FreeCString(valid)
+		FreeCString(invalid)
+	},
+	"GetMetricsJSON":  func() { FreeCString(GetMetricsJSON()) },
+	"GetMemStatsJSON": func() { FreeCString(GetMemStatsJSON()) },
+	"ListExports":     func() { FreeCString(ListExports()) },
+	"TransformBytes": func() {
+		in := cBuffer(16)
+		n := cSizeT(0)
+		FreeBytes(TransformBytes(in, 16, &n))
+		cFree(in)
+	},
+	"Utf16ToUtf8": func() {
+		units := cUShorts([]uint16{'h', 0xD83D, 0xDE80})
+		FreeCString(Utf16ToUtf8(units, 3))
+		cFree(units)
+	},
+	"DivideSafe": func() {
+		FreeResult(DivideSafe(1, 0))
+		FreeResult(DivideSafe(4, 2))
+	},
+	"FormatUnixNanos": func() { FreeCString(FormatUnixNanos(-1)) },
+	"GetConfig": func() {
+		k, v := cString("alloc.key"), cString("value")
+		SetConfig(k, v)
+		FreeCString(GetConfig(k))
+		SetConfig(k, nil)
+		FreeCString(k)
+		FreeCString(v)
+	},
+	"Echo": func() {
+		in := cString("echo")
+		FreeCString(Echo(in))
+		FreeCString(in)
+	},
+	"Base64Decode": func() {
+		in := cBuffer(5)
+		encoded := Base64Encode(in, 5)
+		n := cSizeT(0)
+		FreeBytes(Base64Decode(encoded, &n))
+		FreeCString(encoded)
+		cFree(in)
+	},
+	"ParseInt": func() {
+		good, bad := cString("42"), cString("x")
+		FreeResult(ParseInt(good))
+		FreeResult(ParseInt(bad))
+		FreeCString(good)
+		FreeCString(bad)
+	},
+	"ExportState": func() {
+		snapshot := ExportState()
+		ImportState(snapshot)
+		FreeCString(snapshot)
+	},
+	"MakeRange": func() {
+		n := cSizeT(0)
+		FreeInt64Array(MakeRange(0, 100, &n))
+		FreeInt64Array(MakeRange(3, 3, &n))
+	},
+}
+
+func TestAllocsBalance(t *testing.T) {
+	lockThread(t)
+
+	for name, call := range allocatingExports {
+		t.Run(name, func(t *testing.T) {
+			before := liveAllocs()
+			for i := 0; i < 100; i++ {
+				call()
+			}
+			if live := liveAllocs() - before; live != 0 {
+				t.Errorf("%s leaked %d allocations over 100 calls", name, live)
+			}
+		})
+	}
+}
+
+// TestAllocsTracked makes sure caller-owned memory only comes from the
+// tracked helpers, so TestAllocsBalance sees every allocation.
+func TestAllocsTracked(t *testing.T) {
+	allowed := map[string]bool{
+		"alloc.go":         true, // allocString
+		"bytes.go":         true, // cBytes
+		"arrays.go":        true, // MakeRange
+		"callback_shim.go": true, // freed before returning
+		"cgo_helpers.go":   true, // test helpers
+	}
+
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") || allowed[path] {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			sel, ok := n.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "C" {
+				switch sel.Sel.Name {
+				case "CString", "CBytes", "malloc", "calloc":
+					t.Errorf("%s: C.%s bypasses allocation tracking", fset.Position(sel.Pos()), sel.Sel.Name)
+				}
+			}
+			return true
+		})
+	}
+}
@@ -0,0 +1,14 @@
+//go:build allocdebug
+
+package main
+
+import "sync/atomic"
+
+var allocs atomic.Int64
+
+func trackAlloc() { allocs.Add(1) }
+
+func trackFree() { allocs.Add(-1) }
+
+// liveAllocs returns the number of caller-owned C allocations not yet freed.
+func liveAllocs() int64 { return allocs.Load() }
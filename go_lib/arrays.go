@@ -0,0 +1,151 @@
+package main
+
+/*
+#include <stdlib.h>
+
+// try_malloc is plain malloc: unlike C.malloc, which aborts the process when
+// memory runs out, it returns NULL so the caller can report the failure.
+static inline void *try_malloc(size_t size) {
+	return malloc(size);
+}
+*/
+import "C"
+import (
+	"math"
+	"time"
+	"unsafe"
+)
+
+// SumArray and FillBuffer borrow memory owned by the caller through a pointer
+// and a length. The memory is only valid for the duration of the call, so Go
+// never retains the slice built over it.
+
+// SumArray returns the sum of the len values starting at ptr. A zero length
+// returns 0 without touching ptr; a nil ptr with a nonzero length returns 0
+// and records ErrNullPointer as the last error. A length above the
+// "max_array_len" config value (default 1<<31) is taken to be bogus: it
+// returns 0 and records ErrInvalidArg without reading ptr.
+//
+//export SumArray
+func SumArray(ptr *C.longlong, len C.size_t) (sum C.longlong) {
+	defer trackCall("SumArray", time.Now())
+	recoverToError(func() error {
+		if len == 0 {
+			return nil
+		}
+		if ptr == nil {
+			return newError(ErrNullPointer, "SumArray: nil pointer with length %d", len)
+		}
+		if limit := maxArrayLen(); uint64(len) > limit {
+			return newError(ErrInvalidArg, "SumArray: length %d exceeds max_array_len %d", len, limit)
+		}
+		for _, v := range unsafe.Slice(ptr, len) {
+			sum += v
+		}
+		return nil
+	})
+	return sum
+}
+
+// FillBuffer writes the incrementing pattern 0, 1, 2, ... (wrapping at 256)
+// into the len bytes at ptr and returns the number of bytes written. The
+// buffer stays owned by the caller. A zero length writes nothing; a nil ptr
+// with a nonzero length writes nothing and records ErrNullPointer.
+//
+//export FillBuffer
+func FillBuffer(ptr *C.uchar, len C.size_t) (written C.size_t) {
+	defer trackCall("FillBuffer", time.Now())
+	recoverToError(func() error {
+		if len == 0 {
+			return nil
+		}
+		if ptr == nil {
+			return newError(ErrNullPointer, "FillBuffer: nil pointer with length %d", len)
+		}
+		buf := unsafe.Slice(ptr, len)
+		for i := range buf {
+			buf[i] = C.uchar(i)
+		}
+		written = len
+		return nil
+	})
+	return written
+}
+
+// maxRangeLen bounds MakeRange so the byte size of its result fits in an int.
+const maxRangeLen = math.MaxInt / 8
+
+// MakeRange returns a new C array holding start, start+1, ..., end-1 and
+// writes its length to *outLen. Ownership of the array passes to the caller,
+// who must release it with FreeInt64Array once done; Go keeps no reference to
+// it. start >= end returns nil with *outLen set to 0, and a range too large
+// to allocate returns nil and records ErrInvalidArg.
+//
+//export MakeRange
+func MakeRange(start, end C.longlong, outLen *C.size_t) (out *C.longlong) {
+	defer trackCall("MakeRange", time.Now())
+	recoverToError(func() error {
+		if outLen != nil {
+			*outLen = 0
+		}
+		if start >= end {
+			return nil
+		}
+		n := uint64(end) - uint64(start)
+		if n > maxRangeLen {
+			return newError(ErrInvalidArg, "MakeRange: %d values is too many", n)
+		}
+
+		out = (*C.longlong)(C.try_malloc(C.size_t(n) * C.size_t(unsafe.Sizeof(C.longlong(0)))))
+		if out == nil {
+			return newError(ErrInvalidArg, "MakeRange: cannot allocate %d values", n)
+		}
+		trackAlloc()
+		vals := unsafe.Slice(out, n)
+		for i := range vals {
+			vals[i] = start + C.longlong(i)
+		}
+		if outLen != nil {
+			*outLen = C.size_t(n)
+		}
+		return nil
+	})
+	return out
+}
+
+// FreeInt64Array releases an array returned by MakeRange. Passing nil is a
+// no-op; each array must be freed exactly once.
+//
+//export FreeInt64Array
+func FreeInt64Array(ptr *C.longlong) {
+	defer trackCall("FreeInt64Array", time.Now())
+	if ptr == nil {
+		return
+	}
+	trackFree()
+	C.free(unsafe.Pointer(ptr))
+}
+
+// AddNumbersBatch writes aPtr[i] + bPtr[i] to outPtr[i] for each of the n
+// elements, wrapping on overflow like AddNumbers, in a single FFI crossing.
+// The inputs are borrowed and outPtr must point to n caller-allocated values;
+// it may alias either input. A zero n does nothing; a nil pointer with a
+// nonzero n writes nothing and records ErrNullPointer.
+//
+//export AddNumbersBatch
+func AddNumbersBatch(aPtr *C.longlong, bPtr *C.longlong, outPtr *C.longlong, n C.size_t) {
+	defer trackCall("AddNumbersBatch", time.Now())
+	recoverToError(func() error {
+		if n == 0 {
+			return nil
+		}
+		if aPtr == nil || bPtr == nil || outPtr == nil {
+			return newError(ErrNullPointer, "AddNumbersBatch: nil pointer with length %d", n)
+		}
+		a, b, out := unsafe.Slice(aPtr, n), unsafe.Slice(bPtr, n), unsafe.Slice(outPtr, n)
+		for i := range out {
+			out[i] = a[i] + b[i]
+		}
+		return nil
+	})
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestSumArray(t *testing.T) {
+	vals := []int64{1, 2, 3, -4, 1 << 40}
+	ptr := cLongLongs(vals)
+	defer cFree(ptr)
+
+	if got, want := SumArray(ptr, 5), int64(2+1<<40); int64(got) != want {
+		t.Errorf("SumArray = %d, want %d", got, want)
+	}
+	if got := SumArray(ptr, 2); got != 3 {
+		t.Errorf("SumArray over prefix = %d, want 3", got)
+	}
+}
+
+func TestSumArrayEmptyAndNil(t *testing.T) {
+	lockThread(t)
+
+	if got := SumArray(nil, 0); got != 0 {
+		t.Errorf("SumArray(nil, 0) = %d, want 0", got)
+	}
+	if msg := lastErrorString(); msg != "" {
+		t.Errorf("zero length should not set an error, got %q", msg)
+	}
+
+	if got := SumArray(nil, 3); got != 0 {
+		t.Errorf("SumArray(nil, 3) = %d, want 0", got)
+	}
+	if msg := lastErrorString(); msg == "" {
+		t.Error("nil pointer with nonzero length should set the last error")
+	}
+}
+
+func TestFillBuffer(t *testing.T) {
+	const n = 300
+	buf := cBuffer(n)
+	defer cFree(buf)
+
+	if got := FillBuffer(buf, n); got != n {
+		t.Fatalf("FillBuffer wrote %d bytes, want %d", got, n)
+	}
+	for i, b := range goBytes(buf, n) {
+		if b != byte(i) {
+			t.Fatalf("byte %d = %d, want %d", i, b, byte(i))
+		}
+	}
+}
+
+func TestFillBufferEmptyAndNil(t *testing.T) {
+	lockThread(t)
+
+	if got := FillBuffer(nil, 0); got != 0 {
+		t.Errorf("FillBuffer(nil, 0) = %d, want 0", got)
+	}
+	if got := FillBuffer(nil, 8); got != 0 {
+		t.Errorf("FillBuffer(nil, 8) = %d, want 0", got)
+	}
+	if msg := lastErrorString(); msg == "" {
+		t.Error("nil pointer with nonzero length should set the last error")
+	}
+}
+
+func TestMakeRange(t *testing.T) {
+	outLen := cSizeT(99)
+	ptr := MakeRange(-2, 3, &outLen)
+	if ptr == nil {
+		t.Fatal("MakeRange(-2, 3) returned nil")
+	}
+	defer FreeInt64Array(ptr)
+
+	got := goLongLongs(ptr, int(outLen))
+	want := []int64{-2, -1, 0, 1, 2}
+	if len(got) != len(want) {
+		t.Fatalf("MakeRange(-2, 3) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("MakeRange(-2, 3) = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestMakeRangeEmpty(t *testing.T) {
+	for _, r := range [][2]int64{{5, 5}, {5, 1}} {
+		outLen := cSizeT(99)
+		if ptr := MakeRange(cLongLong(r[0]), cLongLong(r[1]), &outLen); ptr != nil || outLen != 0 {
+			FreeInt64Array(ptr)
+			t.Errorf("MakeRange(%d, %d) = %p, len %d; want nil, 0", r[0], r[1], ptr, outLen)
+		}
+	}
+}
+
+func TestMakeRangeLarge(t *testing.T) {
+	const n = 1 << 20
+	outLen := cSizeT(0)
+	ptr := MakeRange(1<<40, 1<<40+n, &outLen)
+	defer FreeInt64Array(ptr)
+
+	if outLen != n {
+		t.Fatalf("outLen = %d, want %d", outLen, n)
+	}
+	got := goLongLongs(ptr, n)
+	if got[0] != 1<<40 || got[n-1] != 1<<40+n-1 {
+		t.Errorf("range ends = %d, %d", got[0], got[n-1])
+	}
+	if sum := SumArray(ptr, outLen); int64(sum) != n*(1<<40)+n*(n-1)/2 {
+		t.Errorf("SumArray over range = %d", sum)
+	}
+}
+
+func TestMakeRangeTooLarge(t *testing.T) {
+	lockThread(t)
+
+	outLen := cSizeT(99)
+	if ptr := MakeRange(math.MinInt64, math.MaxInt64, &outLen); ptr != nil || outLen != 0 {
+		t.Fatalf("MakeRange over the full int64 range = %p, len %d; want nil, 0", ptr, outLen)
+	}
+	if msg := lastErrorString(); !strings.HasPrefix(msg, "MakeRange:") {
+		t.Errorf("last error = %q, want a MakeRange error", msg)
+	}
+}
+
+func TestMakeRangeAllocFails(t *testing.T) {
+	lockThread(t)
+
+	// 1<<58 values pass the size check but need 2 EiB, which malloc refuses.
+	outLen := cSizeT(99)
+	if ptr := MakeRange(0, 1<<58, &outLen); ptr != nil || outLen != 0 {
+		t.Fatalf("MakeRange(0, 1<<58) = %p, len %d; want nil, 0", ptr, outLen)
+	}
+	if msg := lastErrorString(); !strings.HasPrefix(msg, "MakeRange: cannot allocate") {
+		t.Errorf("last error = %q, want an allocation error", msg)
+	}
+}
+
+func TestFreeInt64ArrayNil(t *testing.T) {
+	FreeInt64Array(nil)
+}
+
+func TestAddNumbersBatch(t *testing.T) {
+	for _, n := range []int{1, 3, 100_000} {
+		a, b := make([]int64, n), make([]int64, n)
+		for i := range a {
+			a[i], b[i] = int64(i), int64(-2*i+7)
+		}
+		aPtr, bPtr, outPtr := cLongLongs(a), cLongLongs(b), cLongLongs(make([]int64, n))
+
+		AddNumbersBatch(aPtr, bPtr, outPtr, cSizeT(n))
+		got := goLongLongs(outPtr, n)
+		for i := range got {
+			if want := a[i] + b[i]; got[i] != want {
+				t.Errorf("n=%d: out[%d] = %d, want %d", n, i, got[i], want)
+				break
+			}
+		}
+		cFree(aPtr)
+		cFree(bPtr)
+		cFree(outPtr)
+	}
+}
+
+func TestAddNumbersBatchEmptyAndNil(t *testing.T) {
+	lockThread(t)
+
+	AddNumbersBatch(nil, nil, nil, 0)
+	if msg := lastErrorString(); msg != "" {
+		t.Errorf("zero length should not set an error, got %q", msg)
+	}
+
+	a := cLongLongs([]int64{1, 2})
+	defer cFree(a)
+	AddNumbersBatch(a, a, nil, 2)
+	if msg := lastErrorString(); !strings.HasPrefix(msg, "AddNumbersBatch:") {
+		t.Errorf("last error = %q, want an AddNumbersBatch error", msg)
+	}
+}
+
+func TestSumArrayMaxLen(t *testing.T) {
+	lockThread(t)
+
+	ptr := cLongLongs([]int64{1, 2, 3, 4})
+	defer cFree(ptr)
+
+	// A bogus length is rejected before anything is read.
+	if got := SumArray(ptr, defaultMaxArrayLen+1); got != 0 {
+		t.Errorf("SumArray over the default cap = %d, want 0", got)
+	}
+	if msg := lastErrorString(); !strings.Contains(msg, "max_array_len") {
+		t.Errorf("last error = %q, want a max_array_len error", msg)
+	}
+
+	setConfig(t, "max_array_len", "4")
+	defer unsetConfig("max_array_len")
+
+	if got := SumArray(ptr, 4); got != 10 {
+		t.Errorf("SumArray at the cap = %d, want 10", got)
+	}
+	if got := SumArray(ptr, 5); got != 0 {
+		t.Errorf("SumArray past the cap = %d, want 0", got)
+	}
+	if msg := lastErrorString(); !strings.Contains(msg, "max_array_len 4") {
+		t.Errorf("last error = %q, want a max_array_len 4 error", msg)
+	}
+
+	setConfig(t, "max_array_len", "lots")
+	if got := maxArrayLen(); got != defaultMaxArrayLen {
+		t.Errorf("maxArrayLen with an invalid value = %d, want the default", got)
+	}
+}
+
+func TestSumArrayLargeWithinCap(t *testing.T) {
+	const n = 1 << 20
+	outLen := cSizeT(0)
+	ptr := MakeRange(0, n, &outLen)
+	defer FreeInt64Array(ptr)
+
+	if got := SumArray(ptr, outLen); got != n*(n-1)/2 {
+		t.Errorf("SumArray over %d values = %d, want %d", n, got, n*(n-1)/2)
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+import "C"
+import "time"
+
+// NoOp does nothing. It is the one export that skips trackCall and
+// recoverToError, so timing it measures the bare cost of crossing into Go
+// and back (BenchmarkNoOp).
+//
+//export NoOp
+func NoOp() {}
+
+// BusyWork adds 0, 1, ..., iterations-1 in a plain loop and returns the sum,
+// wrapping on overflow; zero or negative iterations return 0. Comparing it
+// with NoOp separates compute cost from crossing cost (BenchmarkBusyWork).
+//
+//export BusyWork
+func BusyWork(iterations C.longlong) (sum C.longlong) {
+	defer trackCall("BusyWork", time.Now())
+	recoverToError(func() error {
+		for i := C.longlong(0); i < iterations; i++ {
+			sum += i
+		}
+		return nil
+	})
+	return sum
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Rough numbers from a Linux x86-64 VM, for spotting order-of-magnitude
+// regressions rather than for exact comparisons:
+//
+//	BenchmarkNoOp                       ~90 ns/op  (C-to-Go crossing only)
+//	BenchmarkBusyWork/iters=1          ~400 ns/op  (plus trackCall and recoverToError)
+//	BenchmarkBusyWork/iters=1000       ~3.3 µs/op
+//	BenchmarkBusyWork/iters=1000000    ~2.8 ms/op
+//
+// Run with: go test -run XXX -bench 'NoOp|BusyWork'
+
+var busyWorkSink int64
+
+func TestBusyWork(t *testing.T) {
+	for _, tt := range []struct{ iterations, want int64 }{
+		{-5, 0}, {0, 0}, {1, 0}, {4, 6}, {1000, 499500},
+	} {
+		if got := BusyWork(cLongLong(tt.iterations)); int64(got) != tt.want {
+			t.Errorf("BusyWork(%d) = %d, want %d", tt.iterations, got, tt.want)
+		}
+	}
+	if got := callBusyWork(3, 10); got != 135 {
+		t.Errorf("three BusyWork(10) calls from C = %d, want 135", got)
+	}
+	callNoOp(3)
+}
+
+func BenchmarkNoOp(b *testing.B) {
+	callNoOp(b.N)
+}
+
+func BenchmarkBusyWork(b *testing.B) {
+	for _, iterations := range []int64{1, 1000, 1_000_000} {
+		b.Run(fmt.Sprintf("iters=%d", iterations), func(b *testing.B) {
+			busyWorkSink = callBusyWork(b.N, iterations)
+		})
+	}
+}
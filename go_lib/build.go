@@ -0,0 +1,51 @@
+//go:build ignore
+
+// build compiles the library with -buildmode=c-shared and names the artifact
+// after the version declared in go_lib.go, e.g. go_lib-0.1.0.dll on Windows.
+// The cgo header is written next to it with the same base name.
+//
+// Usage (from go_lib):
+//
+//	go run build.go [-o dir]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"go_lib/internal/buildinfo"
+)
+
+func main() {
+	outDir := flag.String("o", ".", "directory to write the library and header to")
+	flag.Parse()
+
+	version, err := buildinfo.Version("go_lib.go")
+	if err != nil {
+		log.Fatalf("build: %v", err)
+	}
+
+	goos := os.Getenv("GOOS")
+	if goos == "" {
+		goos = runtime.GOOS
+	}
+
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		log.Fatalf("build: %v", err)
+	}
+	name := fmt.Sprintf("%s-%s%s", buildinfo.LibName, version, buildinfo.SharedLibExt(goos))
+	artifact := filepath.Join(*outDir, name)
+
+	cmd := exec.Command("go", "build", "-buildmode=c-shared", "-o", artifact, ".")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		log.Fatalf("build: go build failed: %v", err)
+	}
+	fmt.Println(artifact)
+}
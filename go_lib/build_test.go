@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"go_lib/internal/buildinfo"
+)
+
+func TestVersionedBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping shared library build in short mode")
+	}
+
+	dir := t.TempDir()
+	out, err := exec.Command("go", "run", "build.go", "-o", dir).CombinedOutput()
+	if err != nil {
+		t.Fatalf("go run build.go: %v\n%s", err, out)
+	}
+
+	ext := buildinfo.SharedLibExt(runtime.GOOS)
+	base := fmt.Sprintf("go_lib-%d.%d.%d", versionMajor, versionMinor, versionPatch)
+
+	for _, name := range []string{base + ext, base + ".h"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected artifact %s: %v", name, err)
+		}
+	}
+}
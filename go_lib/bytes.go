@@ -0,0 +1,144 @@
+package main
+
+// #include <stdlib.h>
+import "C"
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"time"
+	"unsafe"
+)
+
+// Byte-buffer results are allocated with C.malloc, carry their length in an
+// out-parameter and must be released with FreeBytes.
+
+// cBytes copies b into a new C buffer. It returns nil for an empty b.
+func cBytes(b []byte) *C.uchar {
+	if len(b) == 0 {
+		return nil
+	}
+	trackAlloc()
+	return (*C.uchar)(C.CBytes(b))
+}
+
+// TransformBytes returns a new buffer holding the inLen bytes at in in
+// reverse order and writes its length to *outLen. The input is borrowed.
+// Empty input returns nil with *outLen set to 0; a nil in with a nonzero
+// inLen returns nil and records ErrNullPointer.
+//
+//export TransformBytes
+func TransformBytes(in *C.uchar, inLen C.size_t, outLen *C.size_t) (out *C.uchar) {
+	defer trackCall("TransformBytes", time.Now())
+	recoverToError(func() error {
+		if outLen != nil {
+			*outLen = 0
+		}
+		if inLen == 0 {
+			return nil
+		}
+		if in == nil {
+			return newError(ErrNullPointer, "TransformBytes: nil input with length %d", inLen)
+		}
+
+		src := unsafe.Slice((*byte)(unsafe.Pointer(in)), inLen)
+		reversed := make([]byte, len(src))
+		for i, b := range src {
+			reversed[len(src)-1-i] = b
+		}
+
+		out = cBytes(reversed)
+		if outLen != nil {
+			*outLen = inLen
+		}
+		return nil
+	})
+	return out
+}
+
+// FreeBytes releases a buffer returned by this library. Passing nil is a
+// no-op; each buffer must be freed exactly once.
+//
+//export FreeBytes
+func FreeBytes(ptr *C.uchar) {
+	defer trackCall("FreeBytes", time.Now())
+	if ptr == nil {
+		return
+	}
+	trackFree()
+	C.free(unsafe.Pointer(ptr))
+}
+
+// Sha256 writes the SHA-256 digest of the inLen bytes at in to out, which
+// must point to a caller-allocated buffer of exactly 32 bytes. A nil in with
+// a nonzero inLen, or a nil out, records ErrNullPointer and leaves out
+// untouched.
+//
+//export Sha256
+func Sha256(in *C.uchar, inLen C.size_t, out *C.uchar) {
+	defer trackCall("Sha256", time.Now())
+	recoverToError(func() error {
+		if in == nil && inLen > 0 {
+			return newError(ErrNullPointer, "Sha256: nil input with length %d", inLen)
+		}
+		if out == nil {
+			return newError(ErrNullPointer, "Sha256: nil output buffer")
+		}
+
+		var data []byte
+		if inLen > 0 {
+			data = unsafe.Slice((*byte)(unsafe.Pointer(in)), inLen)
+		}
+		digest := sha256.Sum256(data)
+		copy(unsafe.Slice((*byte)(unsafe.Pointer(out)), sha256.Size), digest[:])
+		return nil
+	})
+}
+
+// Base64Encode returns the inLen bytes at in as standard, padded base64 in a
+// new C string to be released with FreeCString. Empty input returns "". A
+// nil in with a nonzero inLen returns nil and records ErrNullPointer.
+//
+//export Base64Encode
+func Base64Encode(in *C.uchar, inLen C.size_t) (out *C.char) {
+	defer trackCall("Base64Encode", time.Now())
+	recoverToError(func() error {
+		if in == nil && inLen > 0 {
+			return newError(ErrNullPointer, "Base64Encode: nil input with length %d", inLen)
+		}
+		var src []byte
+		if inLen > 0 {
+			src = unsafe.Slice((*byte)(unsafe.Pointer(in)), inLen)
+		}
+		out = allocString(base64.StdEncoding.EncodeToString(src))
+		return nil
+	})
+	return out
+}
+
+// Base64Decode decodes the standard, padded base64 string in into a new
+// buffer to be released with FreeBytes and writes its length to *outLen.
+// An empty string returns nil with *outLen set to 0. Invalid base64 returns
+// nil and records ErrInvalidArg; a nil in records ErrNullPointer.
+//
+//export Base64Decode
+func Base64Decode(in *C.char, outLen *C.size_t) (out *C.uchar) {
+	defer trackCall("Base64Decode", time.Now())
+	recoverToError(func() error {
+		if outLen != nil {
+			*outLen = 0
+		}
+		if in == nil {
+			return newError(ErrNullPointer, "Base64Decode: nil input")
+		}
+		decoded, err := base64.StdEncoding.DecodeString(C.GoString(in))
+		if err != nil {
+			return newError(ErrInvalidArg, "Base64Decode: %v", err)
+		}
+		out = cBytes(decoded)
+		if outLen != nil {
+			*outLen = C.size_t(len(decoded))
+		}
+		return nil
+	})
+	return out
+}
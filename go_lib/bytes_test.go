@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestTransformBytes(t *testing.T) {
+	input := []byte{1, 2, 3, 0, 255}
+	in := cBuffer(len(input))
+	defer cFree(in)
+	copy(goBytesView(in, len(input)), input)
+
+	outLen := cSizeT(99)
+	out := TransformBytes(in, cSizeT(len(input)), &outLen)
+	if out == nil {
+		t.Fatal("TransformBytes returned nil")
+	}
+	defer FreeBytes(out)
+
+	if got, want := goBytes(out, int(outLen)), []byte{255, 0, 3, 2, 1}; !bytes.Equal(got, want) {
+		t.Errorf("TransformBytes = %v, want %v", got, want)
+	}
+}
+
+func TestTransformBytesEmptyAndNil(t *testing.T) {
+	lockThread(t)
+
+	outLen := cSizeT(99)
+	if out := TransformBytes(nil, 0, &outLen); out != nil || outLen != 0 {
+		t.Errorf("TransformBytes(empty) = %p, len %d; want nil, 0", out, outLen)
+	}
+
+	if out := TransformBytes(nil, 4, &outLen); out != nil {
+		t.Error("TransformBytes(nil, 4) should return nil")
+	}
+	if msg := lastErrorString(); msg == "" {
+		t.Error("nil input with nonzero length should set the last error")
+	}
+}
+
+func TestSha256(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{"abc", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+	}
+	for _, tt := range tests {
+		in := cBuffer(len(tt.input) + 1)
+		copy(goBytesView(in, len(tt.input)), tt.input)
+		out := cBuffer(32)
+
+		Sha256(in, cSizeT(len(tt.input)), out)
+		if got := hex.EncodeToString(goBytes(out, 32)); got != tt.want {
+			t.Errorf("Sha256(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+		cFree(in)
+		cFree(out)
+	}
+}
+
+func TestSha256NilInput(t *testing.T) {
+	lockThread(t)
+
+	out := cBuffer(32)
+	defer cFree(out)
+
+	Sha256(nil, 3, out)
+	if msg := lastErrorString(); msg == "" {
+		t.Error("nil input with nonzero length should set the last error")
+	}
+	if got := goBytes(out, 32); !bytes.Equal(got, make([]byte, 32)) {
+		t.Errorf("output was modified: %x", got)
+	}
+}
+
+func TestBase64KnownVector(t *testing.T) {
+	input := []byte("hello, world")
+	in := cBuffer(len(input))
+	defer cFree(in)
+	copy(goBytesView(in, len(input)), input)
+
+	out := Base64Encode(in, cSizeT(len(input)))
+	defer FreeCString(out)
+	if got, want := goString(out), "aGVsbG8sIHdvcmxk"; got != want {
+		t.Errorf("Base64Encode(%q) = %q, want %q", input, got, want)
+	}
+}
+
+func TestBase64RoundTrip(t *testing.T) {
+	inputs := [][]byte{{0}, {255, 254}, []byte("abc"), bytes.Repeat([]byte{0, 1, 2, 250}, 1000)}
+	for _, input := range inputs {
+		in := cBuffer(len(input))
+		copy(goBytesView(in, len(input)), input)
+		encoded := Base64Encode(in, cSizeT(len(input)))
+		cFree(in)
+
+		outLen := cSizeT(0)
+		decoded := Base64Decode(encoded, &outLen)
+		FreeCString(encoded)
+		if got := goBytes(decoded, int(outLen)); !bytes.Equal(got, input) {
+			t.Errorf("round trip of %d bytes = %x, want %x", len(input), got, input)
+		}
+		FreeBytes(decoded)
+	}
+}
+
+func TestBase64Empty(t *testing.T) {
+	out := Base64Encode(nil, 0)
+	if out == nil || goString(out) != "" {
+		t.Errorf("Base64Encode(empty) = %p, want an empty string", out)
+	}
+	FreeCString(out)
+
+	empty := cString("")
+	defer FreeCString(empty)
+	outLen := cSizeT(99)
+	if decoded := Base64Decode(empty, &outLen); decoded != nil || outLen != 0 {
+		FreeBytes(decoded)
+		t.Errorf("Base64Decode(\"\") = %p, len %d; want nil, 0", decoded, outLen)
+	}
+}
+
+func TestBase64DecodeInvalid(t *testing.T) {
+	lockThread(t)
+
+	for _, s := range []string{"not base64!", "abc", "aGVsbG8=x"} {
+		in := cString(s)
+		outLen := cSizeT(99)
+		if decoded := Base64Decode(in, &outLen); decoded != nil || outLen != 0 {
+			FreeBytes(decoded)
+			t.Errorf("Base64Decode(%q) = %p, len %d; want nil, 0", s, decoded, outLen)
+		}
+		if msg := lastErrorString(); !strings.HasPrefix(msg, "Base64Decode:") {
+			t.Errorf("Base64Decode(%q) last error = %q", s, msg)
+		}
+		FreeCString(in)
+	}
+
+	if decoded := Base64Decode(nil, nil); decoded != nil {
+		t.Error("Base64Decode(nil) should return nil")
+	}
+	if out := Base64Encode(nil, 3); out != nil {
+		FreeCString(out)
+		t.Error("Base64Encode(nil, 3) should return nil")
+	}
+}
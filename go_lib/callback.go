@@ -0,0 +1,73 @@
+package main
+
+// #include <stdint.h>
+import "C"
+import (
+	"sync"
+	"time"
+)
+
+var (
+	callbackMu sync.RWMutex
+	callback   uintptr
+)
+
+// RegisterCallback stores a C function pointer of type void (*)(long long)
+// for TriggerCallback to invoke. Registering 0 removes the callback.
+//
+//export RegisterCallback
+func RegisterCallback(cb C.uintptr_t) {
+	defer trackCall("RegisterCallback", time.Now())
+	recoverToError(func() error {
+		callbackMu.Lock()
+		callback = uintptr(cb)
+		callbackMu.Unlock()
+		return nil
+	})
+}
+
+// TriggerCallback invokes the registered callback with value. It returns
+// ErrNullPointer without calling anything if no callback is registered.
+//
+//export TriggerCallback
+func TriggerCallback(value C.longlong) C.int {
+	defer trackCall("TriggerCallback", time.Now())
+	return recoverToError(func() error {
+		callbackMu.RLock()
+		cb := callback
+		callbackMu.RUnlock()
+
+		if cb == 0 {
+			return newError(ErrNullPointer, "TriggerCallback: no callback registered")
+		}
+		invokeLongLongCallback(cb, int64(value))
+		return nil
+	})
+}
+
+// StreamRange calls cb, a C function pointer of type int (*)(long long),
+// once for each integer in [start, end) in order and returns how many values
+// it delivered. A nonzero return from cb stops the stream after that value,
+// which still counts as delivered. An empty range delivers nothing and
+// returns 0; a zero cb returns -1 and records ErrNullPointer.
+//
+//export StreamRange
+func StreamRange(start, end C.longlong, cb C.uintptr_t) (delivered C.longlong) {
+	defer trackCall("StreamRange", time.Now())
+	code := recoverToError(func() error {
+		if cb == 0 {
+			return newError(ErrNullPointer, "StreamRange: nil callback")
+		}
+		for v := start; v < end; v++ {
+			delivered++
+			if invokeStreamCallback(uintptr(cb), int64(v)) {
+				break
+			}
+		}
+		return nil
+	})
+	if code != ErrOK {
+		return -1
+	}
+	return delivered
+}
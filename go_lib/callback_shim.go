@@ -0,0 +1,43 @@
+package main
+
+/*
+#include <stdint.h>
+#include <stdlib.h>
+
+typedef void (*longlong_callback)(long long);
+typedef void (*log_callback)(const char *);
+typedef int (*stream_callback)(long long);
+
+static inline void invoke_longlong_callback(uintptr_t cb, long long value) {
+	((longlong_callback)cb)(value);
+}
+
+static inline void invoke_log_callback(uintptr_t cb, const char *msg) {
+	((log_callback)cb)(msg);
+}
+
+static inline int invoke_stream_callback(uintptr_t cb, long long value) {
+	return ((stream_callback)cb)(value);
+}
+*/
+import "C"
+import "unsafe"
+
+// cgo cannot call a C function pointer directly, so callbacks registered by
+// the host are invoked through the shims above. They live in a file without
+// //export so the definitions stay out of the generated header.
+
+func invokeLongLongCallback(cb uintptr, value int64) {
+	C.invoke_longlong_callback(C.uintptr_t(cb), C.longlong(value))
+}
+
+// invokeStreamCallback reports whether the callback asked to stop.
+func invokeStreamCallback(cb uintptr, value int64) bool {
+	return C.invoke_stream_callback(C.uintptr_t(cb), C.longlong(value)) != 0
+}
+
+func invokeLogCallback(cb uintptr, msg string) {
+	cmsg := C.CString(msg)
+	defer C.free(unsafe.Pointer(cmsg))
+	C.invoke_log_callback(C.uintptr_t(cb), cmsg)
+}
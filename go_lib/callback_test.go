@@ -0,0 +1,67 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTriggerCallbackUnregistered(t *testing.T) {
+	RegisterCallback(0)
+	if code := TriggerCallback(1); code != ErrNullPointer {
+		t.Errorf("TriggerCallback without registration = %d, want %d", code, ErrNullPointer)
+	}
+}
+
+func TestTriggerCallback(t *testing.T) {
+	RegisterCallback(recordingCallback())
+	defer RegisterCallback(0)
+
+	if code := TriggerCallback(42); code != ErrOK {
+		t.Fatalf("TriggerCallback = %d, want %d", code, ErrOK)
+	}
+	if got := recordedValue(); got != 42 {
+		t.Errorf("callback received %d, want 42", got)
+	}
+}
+
+func TestStreamRange(t *testing.T) {
+	cb := recordingStreamCallback(math.MinInt64)
+	if got := StreamRange(-2, 5, cb); got != 7 {
+		t.Errorf("StreamRange(-2, 5) delivered %d, want 7", got)
+	}
+	if count, sum := streamed(); count != 7 || sum != 7 {
+		t.Errorf("callback saw %d values summing to %d, want 7 and 7", count, sum)
+	}
+}
+
+func TestStreamRangeEarlyStop(t *testing.T) {
+	cb := recordingStreamCallback(3)
+	if got := StreamRange(0, 1000, cb); got != 4 {
+		t.Errorf("StreamRange stopping at 3 delivered %d, want 4", got)
+	}
+	if count, sum := streamed(); count != 4 || sum != 0+1+2+3 {
+		t.Errorf("callback saw %d values summing to %d, want 4 and 6", count, sum)
+	}
+}
+
+func TestStreamRangeEmptyAndNil(t *testing.T) {
+	lockThread(t)
+
+	cb := recordingStreamCallback(math.MinInt64)
+	if got := StreamRange(5, 5, cb); got != 0 {
+		t.Errorf("StreamRange(5, 5) delivered %d, want 0", got)
+	}
+	if got := StreamRange(5, 1, cb); got != 0 {
+		t.Errorf("StreamRange(5, 1) delivered %d, want 0", got)
+	}
+	if count, _ := streamed(); count != 0 {
+		t.Errorf("callback called %d times for empty ranges", count)
+	}
+
+	if got := StreamRange(0, 3, 0); got != -1 {
+		t.Errorf("StreamRange with nil callback = %d, want -1", got)
+	}
+	if msg := lastErrorString(); msg == "" {
+		t.Error("nil callback should set the last error")
+	}
+}
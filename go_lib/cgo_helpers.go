@@ -0,0 +1,224 @@
+package main
+
+/*
+#include <stdint.h>
+#include <stdlib.h>
+#include <string.h>
+
+static long long recorded_value;
+
+static void record_value(long long value) {
+	recorded_value = value;
+}
+
+static uintptr_t record_value_ptr(void) {
+	recorded_value = 0;
+	return (uintptr_t)&record_value;
+}
+
+static long long get_recorded_value(void) {
+	return recorded_value;
+}
+
+static char recorded_message[256];
+
+static void record_message(const char *msg) {
+	strncpy(recorded_message, msg, sizeof(recorded_message) - 1);
+}
+
+static uintptr_t record_message_ptr(void) {
+	recorded_message[0] = '\0';
+	return (uintptr_t)&record_message;
+}
+
+static const char *get_recorded_message(void) {
+	return recorded_message;
+}
+
+static long long streamed_count, streamed_sum, stream_stop_at;
+
+static int record_stream(long long value) {
+	streamed_count++;
+	streamed_sum += value;
+	return value == stream_stop_at;
+}
+
+static uintptr_t record_stream_ptr(long long stop_at) {
+	streamed_count = 0;
+	streamed_sum = 0;
+	stream_stop_at = stop_at;
+	return (uintptr_t)&record_stream;
+}
+
+static long long get_streamed_count(void) {
+	return streamed_count;
+}
+
+static long long get_streamed_sum(void) {
+	return streamed_sum;
+}
+
+// Declared here rather than through the generated header, which cgo only
+// provides to files with //export.
+extern void NoOp(void);
+extern long long BusyWork(long long);
+
+// Loops run in C so each call is a real C-to-Go crossing, the direction a
+// host uses.
+static void call_noop(long long n) {
+	for (long long i = 0; i < n; i++) {
+		NoOp();
+	}
+}
+
+static long long call_busywork(long long n, long long iterations) {
+	long long total = 0;
+	for (long long i = 0; i < n; i++) {
+		total += BusyWork(iterations);
+	}
+	return total;
+}
+*/
+import "C"
+import "unsafe"
+
+// cgo is not available in _test.go files, so the tests reach the C types
+// through these thin wrappers.
+
+func cLongLong(v int64) C.longlong {
+	return C.longlong(v)
+}
+
+func cDouble(v float64) C.double {
+	return C.double(v)
+}
+
+func cInt(v int) C.int {
+	return C.int(v)
+}
+
+func cSizeT(v int) C.size_t {
+	return C.size_t(v)
+}
+
+// cString allocates like the exports do, so tests release it with
+// FreeCString.
+func cString(s string) *C.char {
+	return allocString(s)
+}
+
+func goString(s *C.char) string {
+	return C.GoString(s)
+}
+
+// cLongLongs copies vals into a C-allocated array; release it with cFree.
+func cLongLongs(vals []int64) *C.longlong {
+	if len(vals) == 0 {
+		return nil
+	}
+	ptr := (*C.longlong)(C.malloc(C.size_t(len(vals)) * C.size_t(unsafe.Sizeof(C.longlong(0)))))
+	copy(unsafe.Slice((*int64)(unsafe.Pointer(ptr)), len(vals)), vals)
+	return ptr
+}
+
+// cStringArray copies elems into a C-allocated char* array of C strings;
+// release it with freeCStringArray.
+func cStringArray(elems []string) **C.char {
+	if len(elems) == 0 {
+		return nil
+	}
+	ptr := (**C.char)(C.malloc(C.size_t(len(elems)) * C.size_t(unsafe.Sizeof((*C.char)(nil)))))
+	arr := unsafe.Slice(ptr, len(elems))
+	for i, s := range elems {
+		arr[i] = allocString(s)
+	}
+	return ptr
+}
+
+func freeCStringArray(arr **C.char, n int) {
+	if arr == nil {
+		return
+	}
+	for _, s := range unsafe.Slice(arr, n) {
+		FreeCString(s)
+	}
+	C.free(unsafe.Pointer(arr))
+}
+
+// cUShorts copies units into a C-allocated array; release it with cFree.
+func cUShorts(units []uint16) *C.ushort {
+	if len(units) == 0 {
+		return nil
+	}
+	ptr := (*C.ushort)(C.malloc(C.size_t(len(units)) * C.size_t(unsafe.Sizeof(C.ushort(0)))))
+	copy(unsafe.Slice((*uint16)(unsafe.Pointer(ptr)), len(units)), units)
+	return ptr
+}
+
+// goLongLongs copies the n values at ptr into a Go slice.
+func goLongLongs(ptr *C.longlong, n int) []int64 {
+	if n == 0 {
+		return nil
+	}
+	return append([]int64(nil), unsafe.Slice((*int64)(unsafe.Pointer(ptr)), n)...)
+}
+
+func cFree[T any](ptr *T) {
+	C.free(unsafe.Pointer(ptr))
+}
+
+// recordingCallback returns a C callback that stores its argument for
+// recordedValue to read back.
+func recordingCallback() C.uintptr_t {
+	return C.record_value_ptr()
+}
+
+func recordedValue() int64 {
+	return int64(C.get_recorded_value())
+}
+
+// recordingLogCallback returns a C log callback that keeps a copy of the
+// last message for recordedMessage to read back.
+func recordingLogCallback() C.uintptr_t {
+	return C.record_message_ptr()
+}
+
+func recordedMessage() string {
+	return C.GoString(C.get_recorded_message())
+}
+
+// recordingStreamCallback returns a C stream callback that counts and sums
+// the values it receives and asks to stop once it sees stopAt.
+func recordingStreamCallback(stopAt int64) C.uintptr_t {
+	return C.record_stream_ptr(C.longlong(stopAt))
+}
+
+// streamed returns how many values the stream callback received and their sum.
+func streamed() (count, sum int64) {
+	return int64(C.get_streamed_count()), int64(C.get_streamed_sum())
+}
+
+// callNoOp calls NoOp n times from C.
+func callNoOp(n int) {
+	C.call_noop(C.longlong(n))
+}
+
+// callBusyWork calls BusyWork(iterations) n times from C and returns the sum
+// of the results.
+func callBusyWork(n int, iterations int64) int64 {
+	return int64(C.call_busywork(C.longlong(n), C.longlong(iterations)))
+}
+
+// cBuffer allocates n zeroed bytes on the C heap; release it with cFree.
+func cBuffer(n int) *C.uchar {
+	return (*C.uchar)(C.calloc(C.size_t(n), 1))
+}
+
+func goBytes(ptr *C.uchar, n int) []byte {
+	return C.GoBytes(unsafe.Pointer(ptr), C.int(n))
+}
+
+// goBytesView aliases the n bytes at ptr without copying.
+func goBytesView(ptr *C.uchar, n int) []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(ptr)), n)
+}
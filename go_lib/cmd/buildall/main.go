@@ -0,0 +1,110 @@
+// Command buildall cross-compiles the shared library for every CI target and
+// writes the artifacts to dist/. Each target gets its own cross C compiler,
+// which can be overridden with CC_<GOOS>_<GOARCH> (e.g. CC_LINUX_ARM64).
+// A failing target is reported and the remaining targets are still built;
+// the exit status is nonzero if any of them failed.
+//
+// Usage (from go_lib):
+//
+//	go run ./cmd/buildall [-o dist]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go_lib/internal/buildinfo"
+)
+
+type target struct {
+	GOOS, GOARCH string
+	// CC is the default cross C compiler for the target.
+	CC string
+}
+
+var targets = []target{
+	{GOOS: "linux", GOARCH: "amd64", CC: "x86_64-linux-gnu-gcc"},
+	{GOOS: "linux", GOARCH: "arm64", CC: "aarch64-linux-gnu-gcc"},
+	{GOOS: "darwin", GOARCH: "arm64", CC: "oa64-clang"},
+	{GOOS: "windows", GOARCH: "amd64", CC: "x86_64-w64-mingw32-gcc"},
+}
+
+func (t target) String() string {
+	return t.GOOS + "/" + t.GOARCH
+}
+
+// compiler returns the C compiler for t, honoring CC_<GOOS>_<GOARCH>.
+func (t target) compiler(getenv func(string) string) string {
+	if cc := getenv("CC_" + strings.ToUpper(t.GOOS) + "_" + strings.ToUpper(t.GOARCH)); cc != "" {
+		return cc
+	}
+	return t.CC
+}
+
+// artifactName returns e.g. go_lib-0.1.0-windows-amd64.dll.
+func (t target) artifactName(version string) string {
+	return fmt.Sprintf("%s-%s-%s-%s%s", buildinfo.LibName, version, t.GOOS, t.GOARCH, buildinfo.SharedLibExt(t.GOOS))
+}
+
+// buildCommand assembles the go build invocation for t; the cgo header is
+// written next to the artifact.
+func buildCommand(t target, version, outDir string, environ []string, getenv func(string) string) *exec.Cmd {
+	artifact := filepath.Join(outDir, t.artifactName(version))
+	cmd := exec.Command("go", "build", "-buildmode=c-shared", "-trimpath", "-o", artifact, ".")
+	cmd.Env = append(slices.Clip(environ),
+		"GOOS="+t.GOOS,
+		"GOARCH="+t.GOARCH,
+		"CGO_ENABLED=1",
+		"CC="+t.compiler(getenv),
+	)
+	return cmd
+}
+
+// buildAll builds every target with run, reporting each result to w, and
+// returns the number of targets that failed.
+func buildAll(targets []target, version, outDir string, run func(*exec.Cmd) error, w io.Writer) int {
+	failed := 0
+	for _, t := range targets {
+		cmd := buildCommand(t, version, outDir, os.Environ(), os.Getenv)
+		if err := run(cmd); err != nil {
+			failed++
+			fmt.Fprintf(w, "FAIL %-14s %v\n", t, err)
+			continue
+		}
+		fmt.Fprintf(w, "ok   %-14s %s\n", t, cmd.Args[len(cmd.Args)-2])
+	}
+	return failed
+}
+
+func runCommand(cmd *exec.Cmd) error {
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%v\n%s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func main() {
+	outDir := flag.String("o", "dist", "directory to write the artifacts to")
+	flag.Parse()
+
+	version, err := buildinfo.Version("go_lib.go")
+	if err != nil {
+		log.Fatalf("buildall: %v", err)
+	}
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		log.Fatalf("buildall: %v", err)
+	}
+
+	if failed := buildAll(targets, version, *outDir, runCommand, os.Stdout); failed > 0 {
+		fmt.Fprintf(os.Stderr, "buildall: %d of %d targets failed\n", failed, len(targets))
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func noEnv(string) string { return "" }
+
+func TestBuildCommand(t *testing.T) {
+	tests := []struct {
+		target   target
+		artifact string
+		cc       string
+	}{
+		{targets[0], "go_lib-1.2.3-linux-amd64.so", "x86_64-linux-gnu-gcc"},
+		{targets[1], "go_lib-1.2.3-linux-arm64.so", "aarch64-linux-gnu-gcc"},
+		{targets[2], "go_lib-1.2.3-darwin-arm64.dylib", "oa64-clang"},
+		{targets[3], "go_lib-1.2.3-windows-amd64.dll", "x86_64-w64-mingw32-gcc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target.String(), func(t *testing.T) {
+			cmd := buildCommand(tt.target, "1.2.3", "dist", []string{"PATH=/bin"}, noEnv)
+
+			wantArgs := []string{"go", "build", "-buildmode=c-shared", "-trimpath", "-o", filepath.Join("dist", tt.artifact), "."}
+			if !slices.Equal(cmd.Args, wantArgs) {
+				t.Errorf("args = %q, want %q", cmd.Args, wantArgs)
+			}
+
+			wantEnv := []string{
+				"PATH=/bin",
+				"GOOS=" + tt.target.GOOS,
+				"GOARCH=" + tt.target.GOARCH,
+				"CGO_ENABLED=1",
+				"CC=" + tt.cc,
+			}
+			if !slices.Equal(cmd.Env, wantEnv) {
+				t.Errorf("env = %q, want %q", cmd.Env, wantEnv)
+			}
+		})
+	}
+}
+
+func TestCompilerOverride(t *testing.T) {
+	getenv := func(key string) string {
+		if key == "CC_LINUX_ARM64" {
+			return "zig cc -target aarch64-linux-gnu"
+		}
+		return ""
+	}
+	if got := targets[1].compiler(getenv); got != "zig cc -target aarch64-linux-gnu" {
+		t.Errorf("compiler = %q, want the CC_LINUX_ARM64 override", got)
+	}
+	if got := targets[0].compiler(getenv); got != targets[0].CC {
+		t.Errorf("compiler = %q, want the default %q", got, targets[0].CC)
+	}
+}
+
+func TestBuildAllContinuesAfterFailure(t *testing.T) {
+	var built []string
+	run := func(cmd *exec.Cmd) error {
+		for _, kv := range cmd.Env {
+			if goarch, ok := strings.CutPrefix(kv, "GOARCH="); ok {
+				built = append(built, goarch)
+			}
+		}
+		if slices.Contains(cmd.Env, "GOOS=darwin") {
+			return errors.New("no cross compiler")
+		}
+		return nil
+	}
+
+	var out bytes.Buffer
+	if failed := buildAll(targets, "1.2.3", "dist", run, &out); failed != 1 {
+		t.Errorf("failed = %d, want 1", failed)
+	}
+	if len(built) != len(targets) {
+		t.Errorf("attempted %d targets, want %d", len(built), len(targets))
+	}
+	if !strings.Contains(out.String(), "FAIL darwin/arm64") {
+		t.Errorf("report does not mention the failing target:\n%s", out.String())
+	}
+	if got := strings.Count(out.String(), "ok "); got != 3 {
+		t.Errorf("report has %d successes, want 3:\n%s", got, out.String())
+	}
+}
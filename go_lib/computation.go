@@ -0,0 +1,112 @@
+package main
+
+// #include <stdint.h>
+import "C"
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// cancelCheckInterval is how many iterations run between checks of the
+// context, keeping cancellation prompt without a select on every step.
+const cancelCheckInterval = 1024
+
+type computation struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	result int64
+	err    error
+}
+
+var (
+	computations      sync.Map // uintptr -> *computation
+	nextComputationID atomic.Uintptr
+)
+
+func runComputation(ctx context.Context, c *computation, iterations int64) {
+	defer close(c.done)
+
+	var completed int64
+	for completed < iterations {
+		if completed%cancelCheckInterval == 0 {
+			select {
+			case <-ctx.Done():
+				c.err = newError(ErrCancelled, "computation cancelled after %d of %d iterations", completed, iterations)
+				return
+			default:
+			}
+		}
+		completed++
+	}
+	c.result = completed
+}
+
+// StartComputation runs iterations steps of work in the background and
+// returns a handle for CancelComputation and WaitComputation. A negative
+// iteration count returns 0 and records ErrInvalidArg.
+//
+//export StartComputation
+func StartComputation(iterations C.longlong) (h C.uintptr_t) {
+	defer trackCall("StartComputation", time.Now())
+	recoverToError(func() error {
+		if iterations < 0 {
+			return newError(ErrInvalidArg, "StartComputation: negative iteration count %d", iterations)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		c := &computation{cancel: cancel, done: make(chan struct{})}
+		id := nextComputationID.Add(1)
+		computations.Store(id, c)
+
+		go runComputation(ctx, c, int64(iterations))
+		h = C.uintptr_t(id)
+		return nil
+	})
+	return h
+}
+
+// CancelComputation asks the computation to stop. Cancelling one that
+// already finished or was already cancelled is a no-op; an unknown handle
+// returns ErrInvalidArg.
+//
+//export CancelComputation
+func CancelComputation(h C.uintptr_t) C.int {
+	defer trackCall("CancelComputation", time.Now())
+	return recoverToError(func() error {
+		v, ok := computations.Load(uintptr(h))
+		if !ok {
+			return newError(ErrInvalidArg, "invalid computation handle %d", h)
+		}
+		v.(*computation).cancel()
+		return nil
+	})
+}
+
+// WaitComputation blocks until the computation ends, releases its handle and
+// returns the number of iterations completed. A cancelled computation
+// returns -1 and records ErrCancelled; an unknown handle returns -1 and
+// records ErrInvalidArg.
+//
+//export WaitComputation
+func WaitComputation(h C.uintptr_t) (result C.longlong) {
+	defer trackCall("WaitComputation", time.Now())
+	result = -1
+	recoverToError(func() error {
+		v, ok := computations.LoadAndDelete(uintptr(h))
+		if !ok {
+			return newError(ErrInvalidArg, "invalid computation handle %d", h)
+		}
+		c := v.(*computation)
+		<-c.done
+		c.cancel()
+
+		if c.err != nil {
+			return c.err
+		}
+		result = C.longlong(c.result)
+		return nil
+	})
+	return result
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputationCompletes(t *testing.T) {
+	h := StartComputation(10_000)
+	if h == 0 {
+		t.Fatal("StartComputation returned an invalid handle")
+	}
+	if got := WaitComputation(h); got != 10_000 {
+		t.Errorf("WaitComputation = %d, want 10000", got)
+	}
+	if got := WaitComputation(h); got != -1 {
+		t.Errorf("second WaitComputation = %d, want -1", got)
+	}
+}
+
+func TestComputationCancel(t *testing.T) {
+	lockThread(t)
+
+	h := StartComputation(math.MaxInt64)
+	if code := CancelComputation(h); code != ErrOK {
+		t.Fatalf("CancelComputation = %d, want %d", code, ErrOK)
+	}
+	if code := CancelComputation(h); code != ErrOK {
+		t.Errorf("double CancelComputation = %d, want %d", code, ErrOK)
+	}
+
+	if got := WaitComputation(h); got != -1 {
+		t.Errorf("WaitComputation after cancel = %d, want -1", got)
+	}
+	if msg := lastErrorString(); msg == "" {
+		t.Error("a cancelled computation should set the last error")
+	}
+	if code := CancelComputation(h); code != ErrInvalidArg {
+		t.Errorf("CancelComputation after wait = %d, want %d", code, ErrInvalidArg)
+	}
+}
+
+func TestComputationInvalid(t *testing.T) {
+	if h := StartComputation(-1); h != 0 {
+		t.Errorf("StartComputation(-1) = %d, want 0", h)
+	}
+	if code := CancelComputation(0); code != ErrInvalidArg {
+		t.Errorf("CancelComputation(0) = %d, want %d", code, ErrInvalidArg)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestConcurrentExports calls exports from many goroutines at once, the way
+// host threads would. Run it with -race to check for data races.
+func TestConcurrentExports(t *testing.T) {
+	const workers, rounds = 32, 500
+
+	vals := []int64{1, 2, 3, 4, 5}
+	ptr := cLongLongs(vals)
+	defer cFree(ptr)
+
+	shared := NewAccumulator()
+	defer FreeAccumulator(shared)
+
+	var wg sync.WaitGroup
+	errs := make(chan string, workers)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+
+			own := NewAccumulator()
+			defer FreeAccumulator(own)
+
+			for i := 0; i < rounds; i++ {
+				if got := AddNumbers(cLongLong(int64(w)), cLongLong(int64(i))); int(got) != w+i {
+					errs <- "AddNumbers returned a wrong sum"
+					return
+				}
+				if got := SumArray(ptr, 5); got != 15 {
+					errs <- "SumArray returned a wrong sum"
+					return
+				}
+				AccumulatorAdd(own, 1)
+				AccumulatorAdd(shared, 1)
+			}
+			if got := AccumulatorAdd(own, 0); got != rounds {
+				errs <- "a per-worker accumulator saw another worker's adds"
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	if got := AccumulatorAdd(shared, 0); got != workers*rounds {
+		t.Errorf("shared accumulator total = %d, want %d", got, workers*rounds)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import "C"
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultMaxArrayLen caps borrowed array lengths unless the "max_array_len"
+// config key holds another limit.
+const defaultMaxArrayLen = 1 << 31
+
+var (
+	configMu sync.RWMutex
+	config   = map[string]string{}
+)
+
+func lookupConfig(key string) (string, bool) {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	value, ok := config[key]
+	return value, ok
+}
+
+// maxArrayLen returns the "max_array_len" config value, or
+// defaultMaxArrayLen if it is unset or not a non-negative integer.
+func maxArrayLen() uint64 {
+	if v, ok := lookupConfig("max_array_len"); ok {
+		if n, err := strconv.ParseUint(v, 10, 64); err == nil {
+			return n
+		}
+	}
+	return defaultMaxArrayLen
+}
+
+// SetConfig stores value under key, replacing any previous value; a nil
+// value removes the key. Both strings are copied, so the caller may free
+// them as soon as the call returns. A nil key returns ErrNullPointer.
+//
+//export SetConfig
+func SetConfig(key *C.char, value *C.char) C.int {
+	defer trackCall("SetConfig", time.Now())
+	return recoverToError(func() error {
+		if key == nil {
+			return newError(ErrNullPointer, "SetConfig: nil key")
+		}
+		k := C.GoString(key)
+
+		configMu.Lock()
+		defer configMu.Unlock()
+		if value == nil {
+			delete(config, k)
+		} else {
+			config[k] = C.GoString(value)
+		}
+		return nil
+	})
+}
+
+// GetConfig returns a copy of the value stored under key, or nil if the key
+// is not set, so an absent key can be told apart from an empty value. A nil
+// key returns nil and records ErrNullPointer. The result must be released
+// with FreeCString.
+//
+//export GetConfig
+func GetConfig(key *C.char) (value *C.char) {
+	defer trackCall("GetConfig", time.Now())
+	recoverToError(func() error {
+		if key == nil {
+			return newError(ErrNullPointer, "GetConfig: nil key")
+		}
+		if v, ok := lookupConfig(C.GoString(key)); ok {
+			value = allocString(v)
+		}
+		return nil
+	})
+	return value
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func setConfig(t *testing.T, key, value string) {
+	t.Helper()
+	k, v := cString(key), cString(value)
+	defer FreeCString(k)
+	defer FreeCString(v)
+	if code := SetConfig(k, v); code != ErrOK {
+		t.Errorf("SetConfig(%q, %q) = %d, want %d", key, value, code, ErrOK)
+	}
+}
+
+func unsetConfig(key string) {
+	k := cString(key)
+	defer FreeCString(k)
+	SetConfig(k, nil)
+}
+
+// getConfig returns the value for key and whether it is set.
+func getConfig(key string) (string, bool) {
+	k := cString(key)
+	defer FreeCString(k)
+	v := GetConfig(k)
+	if v == nil {
+		return "", false
+	}
+	defer FreeCString(v)
+	return goString(v), true
+}
+
+func TestConfigSetGet(t *testing.T) {
+	defer unsetConfig("test.level")
+
+	if _, ok := getConfig("test.level"); ok {
+		t.Fatal("missing key should return nil")
+	}
+
+	setConfig(t, "test.level", "debug")
+	if got, ok := getConfig("test.level"); !ok || got != "debug" {
+		t.Errorf("GetConfig = %q, %v; want %q", got, ok, "debug")
+	}
+
+	setConfig(t, "test.level", "")
+	if got, ok := getConfig("test.level"); !ok || got != "" {
+		t.Errorf("GetConfig after overwrite = %q, %v; want empty and set", got, ok)
+	}
+
+	unsetConfig("test.level")
+	if _, ok := getConfig("test.level"); ok {
+		t.Error("key still set after SetConfig with nil value")
+	}
+}
+
+func TestConfigNilKey(t *testing.T) {
+	lockThread(t)
+
+	if code := SetConfig(nil, nil); code != ErrNullPointer {
+		t.Errorf("SetConfig(nil) = %d, want %d", code, ErrNullPointer)
+	}
+	if v := GetConfig(nil); v != nil {
+		FreeCString(v)
+		t.Error("GetConfig(nil) should return nil")
+	}
+	if msg := lastErrorString(); msg == "" {
+		t.Error("GetConfig(nil) should set the last error")
+	}
+}
+
+func TestConfigConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			key := fmt.Sprintf("test.concurrent.%d", g%2)
+			for i := 0; i < 200; i++ {
+				setConfig(t, key, fmt.Sprint(i))
+				if _, ok := getConfig(key); !ok {
+					t.Errorf("%s missing right after SetConfig", key)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	unsetConfig("test.concurrent.0")
+	unsetConfig("test.concurrent.1")
+}
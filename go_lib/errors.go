@@ -0,0 +1,157 @@
+package main
+
+// #include <stdlib.h>
+import "C"
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+//go:generate go run gen_errors.go
+
+// Error codes returned by exports that report failure through a C.int.
+// This block is the single source of truth: gen_errors.go turns it into the
+// Rust FfiError enum, so run `go generate` after editing it.
+const (
+	// ErrOK means the call succeeded.
+	ErrOK = 0
+	// ErrPanic means Go panicked and the panic was recovered.
+	ErrPanic = 1
+	// ErrNullPointer means a required pointer argument was nil.
+	ErrNullPointer = 2
+	// ErrInvalidArg means an argument was outside its accepted range.
+	ErrInvalidArg = 3
+	// ErrCancelled means the operation was cancelled before it finished.
+	ErrCancelled = 4
+	// ErrVersionMismatch means the library is older than the caller requires.
+	ErrVersionMismatch = 5
+	// ErrPartialState means state was restored only in part.
+	ErrPartialState = 6
+)
+
+// ffiError is an error that carries the code reported to the caller.
+type ffiError struct {
+	code C.int
+	msg  string
+}
+
+func (e *ffiError) Error() string {
+	return e.msg
+}
+
+func newError(code C.int, format string, args ...any) error {
+	return &ffiError{code: code, msg: fmt.Sprintf(format, args...)}
+}
+
+// The last error is kept per OS thread, so host threads calling in
+// concurrently never see each other's errors. Goroutines inside Go that rely
+// on it must hold runtime.LockOSThread between setting and reading it.
+var (
+	lastErrorMu sync.RWMutex
+	lastErrors  = map[uint64]string{}
+)
+
+func setLastError(msg string) {
+	tid := currentThreadID()
+
+	lastErrorMu.Lock()
+	if msg == "" {
+		delete(lastErrors, tid)
+	} else {
+		lastErrors[tid] = msg
+	}
+	lastErrorMu.Unlock()
+}
+
+// clearLastError drops the calling thread's last error. It runs on every
+// export call, so the common case, no error recorded by anyone, costs a read
+// lock and skips the cgo call for the thread ID; only a thread that has an
+// entry takes the write lock. Entries are only ever added for the calling
+// thread, so none can appear between the checks and the delete.
+func clearLastError() {
+	lastErrorMu.RLock()
+	empty := len(lastErrors) == 0
+	lastErrorMu.RUnlock()
+	if empty {
+		return
+	}
+
+	tid := currentThreadID()
+	lastErrorMu.RLock()
+	_, ok := lastErrors[tid]
+	lastErrorMu.RUnlock()
+	if !ok {
+		return
+	}
+	lastErrorMu.Lock()
+	delete(lastErrors, tid)
+	lastErrorMu.Unlock()
+}
+
+func loadLastError() string {
+	tid := currentThreadID()
+
+	lastErrorMu.RLock()
+	defer lastErrorMu.RUnlock()
+	return lastErrors[tid]
+}
+
+// recoverToError runs fn and converts its outcome into an error code. A
+// returned error or a panic is recorded as the last error so the caller can
+// fetch the message with GetLastError; a panic never crosses the cgo boundary.
+func recoverToError(fn func() error) (code C.int) {
+	clearLastError()
+	defer func() {
+		if r := recover(); r != nil {
+			setLastError(fmt.Sprintf("go panic: %v", r))
+			code = ErrPanic
+		}
+	}()
+
+	err := fn()
+	if err == nil {
+		return ErrOK
+	}
+	setLastError(err.Error())
+	return errorCode(err)
+}
+
+// errorCode returns the code carried by an *ffiError in err's chain, or
+// ErrInvalidArg for any other non-nil error.
+func errorCode(err error) C.int {
+	if err == nil {
+		return ErrOK
+	}
+	var fe *ffiError
+	if errors.As(err, &fe) {
+		return fe.code
+	}
+	return ErrInvalidArg
+}
+
+// GetLastError returns a copy of the message recorded by the most recent
+// call on the calling thread, or nil if that call succeeded. The result must
+// be released with FreeCString.
+//
+//export GetLastError
+func GetLastError() *C.char {
+	defer trackCall("GetLastError", time.Now())
+	msg := loadLastError()
+	if msg == "" {
+		return nil
+	}
+	return allocString(msg)
+}
+
+// TriggerPanic panics with msg inside recoverToError. It exists so hosts can
+// verify that a Go panic comes back as ErrPanic instead of aborting.
+//
+//export TriggerPanic
+func TriggerPanic(msg *C.char) C.int {
+	defer trackCall("TriggerPanic", time.Now())
+	return recoverToError(func() error {
+		panic(goStringOrEmpty(msg))
+	})
+}
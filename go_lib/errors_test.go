@@ -0,0 +1,179 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// lockThread pins the test goroutine to its OS thread so that the per-thread
+// last error set by one call is visible to the next.
+func lockThread(t *testing.T) {
+	runtime.LockOSThread()
+	t.Cleanup(runtime.UnlockOSThread)
+}
+
+func lastErrorString() string {
+	msg := GetLastError()
+	if msg == nil {
+		return ""
+	}
+	defer FreeCString(msg)
+	return goString(msg)
+}
+
+func TestRecoverToErrorSuccess(t *testing.T) {
+	lockThread(t)
+
+	if code := recoverToError(func() error { return nil }); code != ErrOK {
+		t.Fatalf("code = %d, want %d", code, ErrOK)
+	}
+	if msg := GetLastError(); msg != nil {
+		FreeCString(msg)
+		t.Fatal("GetLastError should be nil after a successful call")
+	}
+}
+
+func TestRecoverToErrorReturnedError(t *testing.T) {
+	lockThread(t)
+
+	code := recoverToError(func() error {
+		return newError(ErrNullPointer, "ptr is nil")
+	})
+	if code != ErrNullPointer {
+		t.Errorf("code = %d, want %d", code, ErrNullPointer)
+	}
+	if msg := lastErrorString(); msg != "ptr is nil" {
+		t.Errorf("last error = %q, want %q", msg, "ptr is nil")
+	}
+
+	code = recoverToError(func() error { return errors.New("plain") })
+	if code != ErrInvalidArg {
+		t.Errorf("code = %d, want %d", code, ErrInvalidArg)
+	}
+}
+
+func TestTriggerPanic(t *testing.T) {
+	lockThread(t)
+
+	msg := cString("index out of range")
+	defer FreeCString(msg)
+
+	if code := TriggerPanic(msg); code != ErrPanic {
+		t.Fatalf("code = %d, want %d", code, ErrPanic)
+	}
+	got := lastErrorString()
+	if !strings.Contains(got, "index out of range") {
+		t.Errorf("last error = %q, want it to contain the panic message", got)
+	}
+
+	// The next successful call clears the slot.
+	AddNumbers(1, 2)
+	if got := lastErrorString(); got != "" {
+		t.Errorf("last error = %q after success, want empty", got)
+	}
+}
+
+func TestLastErrorPerThread(t *testing.T) {
+	const workers, rounds = 16, 200
+
+	var wg sync.WaitGroup
+	errs := make(chan string, workers)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			runtime.LockOSThread()
+			defer runtime.UnlockOSThread()
+
+			for i := 0; i < rounds; i++ {
+				want := fmt.Sprintf("worker %d round %d", w, i)
+				recoverToError(func() error { return newError(ErrInvalidArg, "%s", want) })
+				runtime.Gosched()
+				if got := lastErrorString(); got != want {
+					errs <- fmt.Sprintf("last error = %q, want %q", got, want)
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestSuccessKeepsOtherThreadsError(t *testing.T) {
+	lockThread(t)
+
+	recoverToError(func() error { return newError(ErrInvalidArg, "mine") })
+	done := make(chan string)
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		AddNumbers(1, 2)
+		done <- lastErrorString()
+	}()
+	if got := <-done; got != "" {
+		t.Errorf("other thread's last error = %q, want empty", got)
+	}
+	if got := lastErrorString(); got != "mine" {
+		t.Errorf("last error = %q after another thread's success, want %q", got, "mine")
+	}
+}
+
+// TestFfiErrorInSync checks that every Err* constant in errors.go has a
+// matching variant and from_code arm in the generated Rust enum.
+func TestFfiErrorInSync(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "errors.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated, err := os.ReadFile("../src/ffi_error.rs")
+	if err != nil {
+		t.Fatalf("reading generated enum (run go generate): %v", err)
+	}
+	rust := string(generated)
+
+	found := 0
+	ast.Inspect(file, func(n ast.Node) bool {
+		vs, ok := n.(*ast.ValueSpec)
+		if !ok {
+			return true
+		}
+		for i, name := range vs.Names {
+			if !strings.HasPrefix(name.Name, "Err") {
+				continue
+			}
+			found++
+			value := vs.Values[i].(*ast.BasicLit).Value
+			variant := strings.TrimPrefix(name.Name, "Err")
+			if strings.ToUpper(variant) == variant {
+				variant = variant[:1] + strings.ToLower(variant[1:])
+			}
+
+			for _, want := range []string{
+				fmt.Sprintf("    %s = %s,\n", variant, value),
+				fmt.Sprintf("%s => Some(FfiError::%s),", value, variant),
+			} {
+				if !strings.Contains(rust, want) {
+					t.Errorf("%s: generated Rust is missing %q; run go generate", name.Name, strings.TrimSpace(want))
+				}
+			}
+		}
+		return true
+	})
+	if found == 0 {
+		t.Fatal("no Err* constants found in errors.go")
+	}
+}
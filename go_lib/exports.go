@@ -0,0 +1,35 @@
+package main
+
+import "C"
+import (
+	"encoding/json"
+	"time"
+)
+
+// exportSignature describes one //export function by its C signature.
+// exportSignatures is generated into exports_gen.go by gen_bindings.go.
+type exportSignature struct {
+	Name   string   `json:"name"`
+	Params []string `json:"params"`
+	Result string   `json:"result"`
+}
+
+// ListExports returns every export of the library as a JSON array of
+// {"name":"AddNumbers","params":["long long","long long"],"result":"long long"}
+// objects, with C type names and "void" for no result. Hosts can check it
+// before calling into a library of unknown version. The result must be
+// released with FreeCString.
+//
+//export ListExports
+func ListExports() (out *C.char) {
+	defer trackCall("ListExports", time.Now())
+	recoverToError(func() error {
+		encoded, err := json.Marshal(exportSignatures)
+		if err != nil {
+			return err
+		}
+		out = allocString(string(encoded))
+		return nil
+	})
+	return out
+}
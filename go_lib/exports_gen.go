@@ -0,0 +1,77 @@
+// Code generated by gen_bindings.go; DO NOT EDIT.
+
+package main
+
+var exportSignatures = []exportSignature{
+	{Name: "NewAccumulator", Params: []string{}, Result: "uintptr_t"},
+	{Name: "AccumulatorAdd", Params: []string{"uintptr_t", "long long"}, Result: "long long"},
+	{Name: "FreeAccumulator", Params: []string{"uintptr_t"}, Result: "int"},
+	{Name: "SumArray", Params: []string{"long long*", "size_t"}, Result: "long long"},
+	{Name: "FillBuffer", Params: []string{"unsigned char*", "size_t"}, Result: "size_t"},
+	{Name: "MakeRange", Params: []string{"long long", "long long", "size_t*"}, Result: "long long*"},
+	{Name: "FreeInt64Array", Params: []string{"long long*"}, Result: "void"},
+	{Name: "AddNumbersBatch", Params: []string{"long long*", "long long*", "long long*", "size_t"}, Result: "void"},
+	{Name: "NoOp", Params: []string{}, Result: "void"},
+	{Name: "BusyWork", Params: []string{"long long"}, Result: "long long"},
+	{Name: "TransformBytes", Params: []string{"unsigned char*", "size_t", "size_t*"}, Result: "unsigned char*"},
+	{Name: "FreeBytes", Params: []string{"unsigned char*"}, Result: "void"},
+	{Name: "Sha256", Params: []string{"unsigned char*", "size_t", "unsigned char*"}, Result: "void"},
+	{Name: "Base64Encode", Params: []string{"unsigned char*", "size_t"}, Result: "char*"},
+	{Name: "Base64Decode", Params: []string{"char*", "size_t*"}, Result: "unsigned char*"},
+	{Name: "RegisterCallback", Params: []string{"uintptr_t"}, Result: "void"},
+	{Name: "TriggerCallback", Params: []string{"long long"}, Result: "int"},
+	{Name: "StreamRange", Params: []string{"long long", "long long", "uintptr_t"}, Result: "long long"},
+	{Name: "StartComputation", Params: []string{"long long"}, Result: "uintptr_t"},
+	{Name: "CancelComputation", Params: []string{"uintptr_t"}, Result: "int"},
+	{Name: "WaitComputation", Params: []string{"uintptr_t"}, Result: "long long"},
+	{Name: "SetConfig", Params: []string{"char*", "char*"}, Result: "int"},
+	{Name: "GetConfig", Params: []string{"char*"}, Result: "char*"},
+	{Name: "GetLastError", Params: []string{}, Result: "char*"},
+	{Name: "TriggerPanic", Params: []string{"char*"}, Result: "int"},
+	{Name: "ListExports", Params: []string{}, Result: "char*"},
+	{Name: "AddFloats", Params: []string{"double", "double"}, Result: "double"},
+	{Name: "DivideFloats", Params: []string{"double", "double"}, Result: "double"},
+	{Name: "IsNaN", Params: []string{"double"}, Result: "int"},
+	{Name: "GetDLLVersion", Params: []string{}, Result: "long long"},
+	{Name: "GetDLLVersionString", Params: []string{}, Result: "char*"},
+	{Name: "VersionAtLeast", Params: []string{"long long", "long long", "long long"}, Result: "int"},
+	{Name: "RequireVersion", Params: []string{"long long", "long long", "long long"}, Result: "int"},
+	{Name: "GetVersionComponents", Params: []string{"long long*", "long long*", "long long*"}, Result: "void"},
+	{Name: "FreeCString", Params: []string{"char*"}, Result: "void"},
+	{Name: "ConcatStrings", Params: []string{"char*", "char*"}, Result: "char*"},
+	{Name: "JoinStrings", Params: []string{"char**", "int", "char*"}, Result: "char*"},
+	{Name: "GoFunction", Params: []string{}, Result: "void"},
+	{Name: "AddNumbers", Params: []string{"long long", "long long"}, Result: "long long"},
+	{Name: "Ping", Params: []string{"long long"}, Result: "long long"},
+	{Name: "Echo", Params: []string{"char*"}, Result: "char*"},
+	{Name: "IsEven", Params: []string{"long long"}, Result: "int"},
+	{Name: "MultiplyNumbers", Params: []string{"long long", "long long"}, Result: "long long"},
+	{Name: "ModNumbers", Params: []string{"long long", "long long"}, Result: "long long"},
+	{Name: "AddChecked", Params: []string{"long long", "long long", "int*"}, Result: "long long"},
+	{Name: "DivMod", Params: []string{"long long", "long long", "long long*", "long long*"}, Result: "int"},
+	{Name: "MaybeDouble", Params: []string{"long long", "int", "int*"}, Result: "long long"},
+	{Name: "CompareInt", Params: []string{"long long", "long long"}, Result: "int"},
+	{Name: "ProcessJSON", Params: []string{"char*"}, Result: "char*"},
+	{Name: "LibInit", Params: []string{}, Result: "int"},
+	{Name: "LibShutdown", Params: []string{}, Result: "void"},
+	{Name: "SetLogCallback", Params: []string{"uintptr_t"}, Result: "void"},
+	{Name: "GetMemStatsJSON", Params: []string{}, Result: "char*"},
+	{Name: "ForceGC", Params: []string{}, Result: "void"},
+	{Name: "GetMetricsJSON", Params: []string{}, Result: "char*"},
+	{Name: "ResetMetrics", Params: []string{}, Result: "void"},
+	{Name: "ParallelSquare", Params: []string{"long long*", "long long*", "size_t", "int"}, Result: "void"},
+	{Name: "ProcessPoint", Params: []string{"Point"}, Result: "double"},
+	{Name: "MakePoint", Params: []string{"long long", "long long", "double"}, Result: "Point"},
+	{Name: "SeedRandom", Params: []string{"long long"}, Result: "void"},
+	{Name: "NextRandom", Params: []string{}, Result: "long long"},
+	{Name: "DivideSafe", Params: []string{"long long", "long long"}, Result: "FfiResult"},
+	{Name: "ParseInt", Params: []string{"char*"}, Result: "FfiResult"},
+	{Name: "FreeResult", Params: []string{"FfiResult"}, Result: "void"},
+	{Name: "ExportState", Params: []string{}, Result: "char*"},
+	{Name: "ImportState", Params: []string{"char*"}, Result: "int"},
+	{Name: "Utf8Len", Params: []string{"char*"}, Result: "long long"},
+	{Name: "IsValidUtf8", Params: []string{"char*"}, Result: "int"},
+	{Name: "Utf16ToUtf8", Params: []string{"unsigned short*", "size_t"}, Result: "char*"},
+	{Name: "FormatUnixNanos", Params: []string{"long long"}, Result: "char*"},
+	{Name: "ParseRFC3339", Params: []string{"char*"}, Result: "long long"},
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestListExports(t *testing.T) {
+	out := ListExports()
+	if out == nil {
+		t.Fatal("ListExports returned nil")
+	}
+	defer FreeCString(out)
+
+	var got []exportSignature
+	if err := json.Unmarshal([]byte(goString(out)), &got); err != nil {
+		t.Fatalf("ListExports returned invalid JSON: %v", err)
+	}
+	byName := map[string]exportSignature{}
+	for _, sig := range got {
+		byName[sig.Name] = sig
+	}
+
+	want := []struct {
+		name   string
+		params []string
+		result string
+	}{
+		{"AddNumbers", []string{"long long", "long long"}, "long long"},
+		{"GoFunction", []string{}, "void"},
+		{"ConcatStrings", []string{"char*", "char*"}, "char*"},
+		{"ProcessPoint", []string{"Point"}, "double"},
+		{"ListExports", []string{}, "char*"},
+	}
+	for _, w := range want {
+		sig, ok := byName[w.name]
+		if !ok {
+			t.Errorf("%s missing from ListExports", w.name)
+			continue
+		}
+		if len(sig.Params) != len(w.params) || sig.Result != w.result {
+			t.Errorf("%s = %v -> %s, want %v -> %s", w.name, sig.Params, sig.Result, w.params, w.result)
+			continue
+		}
+		for i := range w.params {
+			if sig.Params[i] != w.params[i] {
+				t.Errorf("%s param %d = %q, want %q", w.name, i, sig.Params[i], w.params[i])
+			}
+		}
+	}
+
+	if len(got) != len(expectedPrototypes) {
+		t.Errorf("ListExports lists %d exports, header_test expects %d", len(got), len(expectedPrototypes))
+	}
+}
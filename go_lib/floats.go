@@ -0,0 +1,42 @@
+package main
+
+import "C"
+import (
+	"math"
+	"time"
+)
+
+// Floating-point exports follow IEEE-754: division by zero yields +Inf, -Inf
+// or NaN instead of an error.
+
+//export AddFloats
+func AddFloats(a, b C.double) (sum C.double) {
+	defer trackCall("AddFloats", time.Now())
+	recoverToError(func() error {
+		sum = a + b
+		return nil
+	})
+	return sum
+}
+
+//export DivideFloats
+func DivideFloats(a, b C.double) (quot C.double) {
+	defer trackCall("DivideFloats", time.Now())
+	recoverToError(func() error {
+		quot = a / b
+		return nil
+	})
+	return quot
+}
+
+// IsNaN reports whether x is NaN, returning 1 for true and 0 for false.
+//
+//export IsNaN
+func IsNaN(x C.double) (nan C.int) {
+	defer trackCall("IsNaN", time.Now())
+	recoverToError(func() error {
+		nan = cBool(math.IsNaN(float64(x)))
+		return nil
+	})
+	return nan
+}
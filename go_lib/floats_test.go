@@ -0,0 +1,36 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAddFloats(t *testing.T) {
+	if got := AddFloats(1.5, 2.25); got != 3.75 {
+		t.Errorf("AddFloats(1.5, 2.25) = %v, want 3.75", got)
+	}
+}
+
+func TestDivideFloats(t *testing.T) {
+	if got := DivideFloats(7, 2); got != 3.5 {
+		t.Errorf("DivideFloats(7, 2) = %v, want 3.5", got)
+	}
+	if got := float64(DivideFloats(1, 0)); !math.IsInf(got, 1) {
+		t.Errorf("DivideFloats(1, 0) = %v, want +Inf", got)
+	}
+	if got := float64(DivideFloats(-1, 0)); !math.IsInf(got, -1) {
+		t.Errorf("DivideFloats(-1, 0) = %v, want -Inf", got)
+	}
+	if got := DivideFloats(0, 0); IsNaN(got) != 1 {
+		t.Errorf("DivideFloats(0, 0) = %v, want NaN", got)
+	}
+}
+
+func TestIsNaN(t *testing.T) {
+	if got := IsNaN(1); got != 0 {
+		t.Errorf("IsNaN(1) = %d, want 0", got)
+	}
+	if got := IsNaN(DivideFloats(1, 0)); got != 0 {
+		t.Errorf("IsNaN(+Inf) = %d, want 0", got)
+	}
+}
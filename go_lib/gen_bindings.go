@@ -0,0 +1,408 @@
+//go:build ignore
+
+// gen_bindings parses the //export functions of the package and writes the
+// matching Rust extern "C" declarations, plus the Go registry of C signatures
+// served by ListExports. It fails on any Go type it does not know how to map
+// rather than guessing.
+//
+// Usage (from go_lib):
+//
+//	go run gen_bindings.go [-o file] [-go file] [source.go ...]
+//
+// Without source files it reads every non-test Go file of the package that
+// matches the current build constraints.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// scalarTypes maps cgo scalar types to their Rust equivalents.
+var scalarTypes = map[string]string{
+	"C.char":      "c_char",
+	"C.uchar":     "c_uchar",
+	"C.short":     "c_short",
+	"C.ushort":    "c_ushort",
+	"C.int":       "c_int",
+	"C.uint":      "c_uint",
+	"C.long":      "c_long",
+	"C.ulong":     "c_ulong",
+	"C.longlong":  "i64",
+	"C.ulonglong": "u64",
+	"C.float":     "f32",
+	"C.double":    "f64",
+	"C.size_t":    "usize",
+	"C.uintptr_t": "usize",
+}
+
+// Rust keywords that may appear as Go parameter names.
+var rustKeywords = map[string]bool{
+	"as": true, "box": true, "crate": true, "dyn": true, "enum": true,
+	"extern": true, "fn": true, "impl": true, "in": true, "let": true,
+	"loop": true, "match": true, "mod": true, "move": true, "mut": true,
+	"pub": true, "ref": true, "self": true, "static": true, "struct": true,
+	"super": true, "trait": true, "type": true, "unsafe": true, "use": true,
+	"where": true, "while": true,
+}
+
+// cTypeNames spells the cgo scalar types whose C name differs from the Go
+// selector.
+var cTypeNames = map[string]string{
+	"uchar":     "unsigned char",
+	"ushort":    "unsigned short",
+	"uint":      "unsigned int",
+	"ulong":     "unsigned long",
+	"longlong":  "long long",
+	"ulonglong": "unsigned long long",
+}
+
+var structTypedef = regexp.MustCompile(`typedef\s+struct\s*\w*\s*\{[^}]*\}\s*(\w+)\s*;`)
+
+type param struct {
+	name, rustType, cType string
+}
+
+type export struct {
+	name    string
+	params  []param
+	result  string
+	cResult string
+}
+
+func main() {
+	out := flag.String("o", "../src/ffi/exports.rs", "Rust file to write")
+	goOut := flag.String("go", "exports_gen.go", "Go signature registry to write")
+	flag.Parse()
+
+	files := flag.Args()
+	if len(files) == 0 {
+		var err error
+		if files, err = packageFiles("."); err != nil {
+			log.Fatalf("gen_bindings: %v", err)
+		}
+	}
+
+	rust, registry, err := generate(files)
+	if err != nil {
+		log.Fatalf("gen_bindings: %v", err)
+	}
+	if err := os.WriteFile(*out, rust, 0o644); err != nil {
+		log.Fatalf("gen_bindings: %v", err)
+	}
+	if err := os.WriteFile(*goOut, registry, 0o644); err != nil {
+		log.Fatalf("gen_bindings: %v", err)
+	}
+}
+
+// packageFiles lists the non-test Go files in dir that are part of the build.
+func packageFiles(dir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, path := range matches {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		ok, err := build.Default.MatchFile(dir, filepath.Base(path))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func generate(files []string) (rust, registry []byte, err error) {
+	fset := token.NewFileSet()
+	var parsed []*ast.File
+	structs := map[string]bool{}
+	for _, path := range files {
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, nil, err
+		}
+		parsed = append(parsed, file)
+		for _, name := range preambleStructs(file) {
+			structs[name] = true
+		}
+	}
+
+	var exports []export
+	for _, file := range parsed {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || !isExported(fn) {
+				continue
+			}
+			e, err := convert(fn, structs)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", fset.Position(fn.Pos()), err)
+			}
+			exports = append(exports, e)
+		}
+	}
+	if len(exports) == 0 {
+		return nil, nil, fmt.Errorf("no //export functions found")
+	}
+	if registry, err = renderRegistry(exports); err != nil {
+		return nil, nil, err
+	}
+	return render(exports, structs), registry, nil
+}
+
+// preambleStructs returns the names of struct typedefs in the cgo preamble.
+func preambleStructs(file *ast.File) []string {
+	var names []string
+	for _, imp := range file.Imports {
+		if imp.Path.Value != `"C"` {
+			continue
+		}
+		doc := imp.Doc
+		if doc == nil {
+			if gen := importDecl(file, imp); gen != nil {
+				doc = gen.Doc
+			}
+		}
+		if doc == nil {
+			continue
+		}
+		for _, m := range structTypedef.FindAllStringSubmatch(doc.Text(), -1) {
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+func importDecl(file *ast.File, imp *ast.ImportSpec) *ast.GenDecl {
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			for _, spec := range gen.Specs {
+				if spec == imp {
+					return gen
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func isExported(fn *ast.FuncDecl) bool {
+	if fn.Doc == nil || fn.Recv != nil {
+		return false
+	}
+	for _, c := range fn.Doc.List {
+		if c.Text == "//export "+fn.Name.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func convert(fn *ast.FuncDecl, structs map[string]bool) (export, error) {
+	e := export{name: fn.Name.Name, cResult: "void"}
+	for _, field := range fn.Type.Params.List {
+		rt, err := rustType(field.Type, structs)
+		if err != nil {
+			return e, err
+		}
+		ct := cType(field.Type)
+		if len(field.Names) == 0 {
+			e.params = append(e.params, param{fmt.Sprintf("arg%d", len(e.params)), rt, ct})
+		}
+		for _, name := range field.Names {
+			e.params = append(e.params, param{rustIdent(name.Name), rt, ct})
+		}
+	}
+
+	if results := fn.Type.Results; results != nil {
+		if results.NumFields() != 1 {
+			return e, fmt.Errorf("%s: exports must return at most one value", fn.Name.Name)
+		}
+		rt, err := rustType(results.List[0].Type, structs)
+		if err != nil {
+			return e, err
+		}
+		e.result = rt
+		e.cResult = cType(results.List[0].Type)
+	}
+	return e, nil
+}
+
+func rustType(expr ast.Expr, structs map[string]bool) (string, error) {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		inner, err := rustType(t.X, structs)
+		if err != nil {
+			return "", err
+		}
+		return "*mut " + inner, nil
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		if !ok {
+			break
+		}
+		name := pkg.Name + "." + t.Sel.Name
+		if rt, ok := scalarTypes[name]; ok {
+			return rt, nil
+		}
+		if pkg.Name == "C" && structs[t.Sel.Name] {
+			return t.Sel.Name, nil
+		}
+		if name == "unsafe.Pointer" {
+			return "*mut c_void", nil
+		}
+	}
+	return "", fmt.Errorf("cannot map Go type %s to Rust", types.ExprString(expr))
+}
+
+// cType spells a type already accepted by rustType the way C declares it.
+func cType(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return cType(t.X) + "*"
+	case *ast.SelectorExpr:
+		if types.ExprString(t) == "unsafe.Pointer" {
+			return "void*"
+		}
+		if name, ok := cTypeNames[t.Sel.Name]; ok {
+			return name
+		}
+		return t.Sel.Name
+	}
+	return types.ExprString(expr)
+}
+
+func rustIdent(name string) string {
+	if rustKeywords[name] {
+		return "r#" + name
+	}
+	return name
+}
+
+func render(exports []export, structs map[string]bool) []byte {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by go_lib/gen_bindings.go; DO NOT EDIT.\n\n")
+
+	var rawTypes []string
+	for _, rt := range []string{"c_char", "c_int", "c_long", "c_short", "c_uchar", "c_uint", "c_ulong", "c_ushort", "c_void"} {
+		if usesType(exports, rt) {
+			rawTypes = append(rawTypes, rt)
+		}
+	}
+	var structNames []string
+	for name := range structs {
+		if usesType(exports, name) {
+			structNames = append(structNames, name)
+		}
+	}
+	sort.Strings(structNames)
+
+	var uses []string
+	if line := useLine("std::os::raw", rawTypes); line != "" {
+		uses = append(uses, line)
+	}
+	if line := useLine("super", structNames); line != "" {
+		uses = append(uses, line)
+	}
+	for _, line := range uses {
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("extern \"C\" {\n")
+	for _, e := range exports {
+		var params []string
+		for _, p := range e.params {
+			params = append(params, p.name+": "+p.rustType)
+		}
+		line := fmt.Sprintf("    pub fn %s(%s)", e.name, strings.Join(params, ", "))
+		if e.result != "" {
+			line += " -> " + e.result
+		}
+		b.WriteString(wrap(line+";", e.name, params, e.result))
+	}
+	b.WriteString("}\n")
+	return b.Bytes()
+}
+
+// renderRegistry writes the Go table of C signatures that ListExports
+// serves, in source order.
+func renderRegistry(exports []export) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen_bindings.go; DO NOT EDIT.\n\n")
+	b.WriteString("package main\n\n")
+	b.WriteString("var exportSignatures = []exportSignature{\n")
+	for _, e := range exports {
+		var params []string
+		for _, p := range e.params {
+			params = append(params, fmt.Sprintf("%q", p.cType))
+		}
+		fmt.Fprintf(&b, "{Name: %q, Params: []string{%s}, Result: %q},\n", e.name, strings.Join(params, ", "), e.cResult)
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
+
+// wrap splits a declaration over several lines the way rustfmt does once it
+// exceeds the 100 column limit.
+func wrap(line, name string, params []string, result string) string {
+	if len(line) <= 100 {
+		return line + "\n"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "    pub fn %s(\n", name)
+	for _, p := range params {
+		fmt.Fprintf(&b, "        %s,\n", p)
+	}
+	b.WriteString("    )")
+	if result != "" {
+		b.WriteString(" -> " + result)
+	}
+	b.WriteString(";\n")
+	return b.String()
+}
+
+// useLine renders a use declaration for names, or "" if there are none.
+func useLine(path string, names []string) string {
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("use %s::%s;\n", path, names[0])
+	default:
+		return fmt.Sprintf("use %s::{%s};\n", path, strings.Join(names, ", "))
+	}
+}
+
+func usesType(exports []export, name string) bool {
+	word := regexp.MustCompile(`\b` + name + `\b`)
+	for _, e := range exports {
+		if word.MatchString(e.result) {
+			return true
+		}
+		for _, p := range e.params {
+			if word.MatchString(p.rustType) {
+				return true
+			}
+		}
+	}
+	return false
+}
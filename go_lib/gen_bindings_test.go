@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files")
+
+// runGenBindings returns the tool output and the generated Rust bindings and
+// Go registry.
+func runGenBindings(t *testing.T, args ...string) (string, []byte, []byte, error) {
+	t.Helper()
+	out := filepath.Join(t.TempDir(), "exports.rs")
+	goOut := filepath.Join(t.TempDir(), "exports_gen.go")
+	cmd := exec.Command("go", append([]string{"run", "gen_bindings.go", "-o", out, "-go", goOut}, args...)...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(output), nil, nil, err
+	}
+	rust, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry, err := os.ReadFile(goOut)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(output), rust, registry, nil
+}
+
+func checkGolden(t *testing.T, golden string, got []byte) {
+	t.Helper()
+	if *updateGolden {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("generated output differs from %s:\n--- got ---\n%s\n--- want ---\n%s", golden, got, want)
+	}
+}
+
+func TestGenBindingsGolden(t *testing.T) {
+	output, rust, registry, err := runGenBindings(t, "testdata/exports.go")
+	if err != nil {
+		t.Fatalf("gen_bindings: %v\n%s", err, output)
+	}
+	checkGolden(t, "testdata/exports.rs.golden", rust)
+	checkGolden(t, "testdata/exports_gen.golden", registry)
+}
+
+func TestGenBindingsUpToDate(t *testing.T) {
+	output, rust, registry, err := runGenBindings(t)
+	if err != nil {
+		t.Fatalf("gen_bindings: %v\n%s", err, output)
+	}
+	for _, f := range []struct {
+		path string
+		got  []byte
+	}{
+		{"../src/ffi/exports.rs", rust},
+		{"exports_gen.go", registry},
+	} {
+		want, err := os.ReadFile(f.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(f.got) != string(want) {
+			t.Errorf("%s is out of date; run go generate", f.path)
+		}
+	}
+}
+
+func TestGenBindingsUnmappableType(t *testing.T) {
+	output, _, _, err := runGenBindings(t, "testdata/unmappable.go")
+	if err == nil {
+		t.Fatal("gen_bindings should fail on a Go string parameter")
+	}
+	if !strings.Contains(output, "cannot map Go type string") {
+		t.Errorf("unexpected error output:\n%s", output)
+	}
+}
@@ -0,0 +1,136 @@
+//go:build ignore
+
+// gen_errors reads the Err* constants from errors.go and writes the matching
+// Rust FfiError enum to ../src/ffi_error.rs.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	sourceFile = "errors.go"
+	outputFile = "../src/ffi_error.rs"
+)
+
+type errorCode struct {
+	goName string
+	value  int
+	doc    string
+}
+
+func main() {
+	codes, err := parseErrorCodes(sourceFile)
+	if err != nil {
+		log.Fatalf("gen_errors: %v", err)
+	}
+	if err := os.WriteFile(outputFile, renderRust(codes), 0o644); err != nil {
+		log.Fatalf("gen_errors: %v", err)
+	}
+}
+
+func parseErrorCodes(path string) ([]errorCode, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var codes []errorCode
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if !strings.HasPrefix(name.Name, "Err") {
+					continue
+				}
+				if i >= len(vs.Values) {
+					return nil, fmt.Errorf("%s: %s has no explicit value", fset.Position(name.Pos()), name.Name)
+				}
+				lit, ok := vs.Values[i].(*ast.BasicLit)
+				if !ok || lit.Kind != token.INT {
+					return nil, fmt.Errorf("%s: %s must be an integer literal", fset.Position(name.Pos()), name.Name)
+				}
+				value, err := strconv.Atoi(lit.Value)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %v", fset.Position(lit.Pos()), err)
+				}
+				codes = append(codes, errorCode{
+					goName: name.Name,
+					value:  value,
+					doc:    strings.TrimSpace(vs.Doc.Text()),
+				})
+			}
+		}
+	}
+	if len(codes) == 0 {
+		return nil, fmt.Errorf("no Err* constants found in %s", path)
+	}
+	return codes, nil
+}
+
+// rustVariant maps a Go constant name to its Rust variant, e.g. ErrOK -> Ok
+// and ErrNullPointer -> NullPointer.
+func rustVariant(goName string) string {
+	name := strings.TrimPrefix(goName, "Err")
+	if strings.ToUpper(name) == name {
+		return name[:1] + strings.ToLower(name[1:])
+	}
+	return name
+}
+
+// rustDoc turns "ErrPanic means Go panicked." into "Go panicked." so the
+// comment reads naturally on the Rust variant.
+func rustDoc(c errorCode) string {
+	doc := strings.Join(strings.Fields(c.doc), " ")
+	doc = strings.TrimPrefix(doc, c.goName+" means ")
+	if doc == "" {
+		return ""
+	}
+	return strings.ToUpper(doc[:1]) + doc[1:]
+}
+
+func renderRust(codes []errorCode) []byte {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by go_lib/gen_errors.go; DO NOT EDIT.\n\n")
+	b.WriteString("/// Error codes returned by the Go library.\n")
+	b.WriteString("#[derive(Debug, Clone, Copy, PartialEq, Eq, Hash)]\n")
+	b.WriteString("#[repr(i32)]\n")
+	b.WriteString("pub enum FfiError {\n")
+	for _, c := range codes {
+		if doc := rustDoc(c); doc != "" {
+			fmt.Fprintf(&b, "    /// %s\n", doc)
+		}
+		fmt.Fprintf(&b, "    %s = %d,\n", rustVariant(c.goName), c.value)
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString("impl FfiError {\n")
+	b.WriteString("    /// Converts a raw code returned by the library into an `FfiError`.\n")
+	b.WriteString("    pub fn from_code(code: i32) -> Option<Self> {\n")
+	b.WriteString("        match code {\n")
+	for _, c := range codes {
+		fmt.Fprintf(&b, "            %d => Some(FfiError::%s),\n", c.value, rustVariant(c.goName))
+	}
+	b.WriteString("            _ => None,\n")
+	b.WriteString("        }\n")
+	b.WriteString("    }\n\n")
+	b.WriteString("    /// Returns the raw code for this error.\n")
+	b.WriteString("    pub fn code(self) -> i32 {\n")
+	b.WriteString("        self as i32\n")
+	b.WriteString("    }\n")
+	b.WriteString("}\n")
+	return b.Bytes()
+}
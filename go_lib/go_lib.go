@@ -1,25 +1,230 @@
 package main
 
+// #include <stdlib.h>
 import "C"
 import (
 	"fmt"
+	"strings"
+	"time"
+	"unsafe"
 )
 
+//go:generate go run gen_bindings.go
+
+const (
+	versionMajor = 0
+	versionMinor = 1
+	versionPatch = 0
+)
+
+// A host that loads the library dynamically calls RequireVersion first.
+// Every other export assumes that call passed: the host may rely on any
+// behavior documented for the required version and must not call an export
+// newer than that version. No initialization call is needed (see LibInit).
+//
+// Every export except GetLastError, NoOp and the Free* functions runs its
+// body through recoverToError so that a panic is reported instead of
+// crashing the host.
+//
+// All exports are safe to call concurrently from multiple host threads.
+// Package-level state (the registered callbacks, the per-thread last error
+// and the handle tables) is guarded by a sync.RWMutex, sync.Map or atomics,
+// and no export keeps a reference to caller memory after it returns.
+// Registered callbacks are invoked on whichever thread makes the triggering
+// call, so they must be thread-safe themselves.
+//
+// cgo has no bool type, so every boolean export returns a C.int that is
+// exactly 1 for true and 0 for false, never any other value.
+//
+// Comparison exports return a C.int that is exactly -1, 0 or 1 as the first
+// operand orders before, equal to or after the second, matching Rust's
+// Ordering (see CompareInt).
+//
+// Exports cannot return several values, so those that produce more than one
+// write them through out-pointer parameters and return an error code (see
+// DivMod). A nil out-pointer skips that output; nothing is written on error.
+
 //export GetDLLVersion
-func GetDLLVersion() C.longlong {
-	// Version format: major * 10000 + minor * 100 + patch
-	// For version 0.1.0 this returns 100
-	return C.longlong(100) // represents 0.1.0
+func GetDLLVersion() (version C.longlong) {
+	defer trackCall("GetDLLVersion", time.Now())
+	recoverToError(func() error {
+		version = packedVersion()
+		return nil
+	})
+	return version
+}
+
+// packedVersion encodes the version as major * 10000 + minor * 100 + patch;
+// for version 0.1.0 this is 100.
+func packedVersion() C.longlong {
+	return C.longlong(versionMajor*10000 + versionMinor*100 + versionPatch)
+}
+
+// GetDLLVersionString returns the version as a semver string such as "0.1.0".
+// The string is allocated on the C heap and must be released with FreeCString.
+//
+//export GetDLLVersionString
+func GetDLLVersionString() (version *C.char) {
+	defer trackCall("GetDLLVersionString", time.Now())
+	recoverToError(func() error {
+		version = allocString(fmt.Sprintf("%d.%d.%d", versionMajor, versionMinor, versionPatch))
+		return nil
+	})
+	return version
+}
+
+// VersionAtLeast returns 1 if the library version is at least
+// major.minor.patch and 0 otherwise.
+//
+//export VersionAtLeast
+func VersionAtLeast(major, minor, patch C.longlong) (ok C.int) {
+	defer trackCall("VersionAtLeast", time.Now())
+	recoverToError(func() error {
+		ok = cBool(compareVersion(int64(major), int64(minor), int64(patch)) >= 0)
+		return nil
+	})
+	return ok
+}
+
+// compareVersion returns -1, 0 or 1 as the library version is below, equal
+// to or above major.minor.patch.
+func compareVersion(major, minor, patch int64) int {
+	have := [3]int64{versionMajor, versionMinor, versionPatch}
+	want := [3]int64{major, minor, patch}
+	for i := range have {
+		if have[i] != want[i] {
+			if have[i] < want[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// RequireVersion returns ErrOK if the library is at least
+// major.minor.patch and ErrVersionMismatch, with the last error naming both
+// versions, otherwise. It is meant to be the first call after loading the
+// library so that a host never calls an export the library lacks.
+//
+//export RequireVersion
+func RequireVersion(major, minor, patch C.longlong) C.int {
+	defer trackCall("RequireVersion", time.Now())
+	return recoverToError(func() error {
+		if compareVersion(int64(major), int64(minor), int64(patch)) < 0 {
+			return newError(ErrVersionMismatch, "RequireVersion: library is %d.%d.%d, %d.%d.%d required",
+				versionMajor, versionMinor, versionPatch, major, minor, patch)
+		}
+		return nil
+	})
+}
+
+// GetVersionComponents unpacks the GetDLLVersion value into its major,
+// minor and patch parts. A nil out-pointer skips that component.
+//
+//export GetVersionComponents
+func GetVersionComponents(major *C.longlong, minor *C.longlong, patch *C.longlong) {
+	defer trackCall("GetVersionComponents", time.Now())
+	recoverToError(func() error {
+		packed := packedVersion()
+		if major != nil {
+			*major = packed / 10000
+		}
+		if minor != nil {
+			*minor = packed % 10000 / 100
+		}
+		if patch != nil {
+			*patch = packed % 100
+		}
+		return nil
+	})
+}
+
+// FreeCString releases a string previously returned by this library.
+// Passing nil is a no-op. Each string must be freed exactly once; freeing
+// it twice or using it after the call is undefined behavior.
+//
+//export FreeCString
+func FreeCString(s *C.char) {
+	defer trackCall("FreeCString", time.Now())
+	if s == nil {
+		return
+	}
+	trackFree()
+	C.free(unsafe.Pointer(s))
+}
+
+// ConcatStrings returns a new C string holding a followed by b. A nil input is
+// treated as the empty string. The inputs are borrowed and stay owned by the
+// caller; the result must be released with FreeCString.
+//
+//export ConcatStrings
+func ConcatStrings(a *C.char, b *C.char) (result *C.char) {
+	defer trackCall("ConcatStrings", time.Now())
+	recoverToError(func() error {
+		result = allocString(goStringOrEmpty(a) + goStringOrEmpty(b))
+		return nil
+	})
+	return result
+}
+
+// JoinStrings joins the count C strings of the argv-style array arr with sep
+// and returns the result as a new C string. Nil elements and a nil sep are
+// treated as empty strings; count == 0 yields "". The array and its strings
+// are borrowed. The result must be released with FreeCString.
+//
+//export JoinStrings
+func JoinStrings(arr **C.char, count C.int, sep *C.char) (result *C.char) {
+	defer trackCall("JoinStrings", time.Now())
+	recoverToError(func() error {
+		if count < 0 {
+			return newError(ErrInvalidArg, "JoinStrings: negative count %d", count)
+		}
+		if arr == nil && count > 0 {
+			return newError(ErrNullPointer, "JoinStrings: nil array with count %d", count)
+		}
+		parts := make([]string, count)
+		for i, s := range unsafe.Slice(arr, int(count)) {
+			parts[i] = goStringOrEmpty(s)
+		}
+		result = allocString(strings.Join(parts, goStringOrEmpty(sep)))
+		return nil
+	})
+	return result
+}
+
+func goStringOrEmpty(s *C.char) string {
+	if s == nil {
+		return ""
+	}
+	return C.GoString(s)
+}
+
+// cBool converts b to the 0/1 C.int used by boolean exports.
+func cBool(b bool) C.int {
+	if b {
+		return 1
+	}
+	return 0
 }
 
 //export GoFunction
 func GoFunction() {
-	fmt.Println("Hello from Go!")
+	defer trackCall("GoFunction", time.Now())
+	recoverToError(func() error {
+		logInfo("Hello from Go!")
+		return nil
+	})
 }
 
 //export AddNumbers
-func AddNumbers(a, b C.longlong) C.longlong {
-	return a + b
+func AddNumbers(a, b C.longlong) (sum C.longlong) {
+	defer trackCall("AddNumbers", time.Now())
+	recoverToError(func() error {
+		sum = a + b
+		return nil
+	})
+	return sum
 }
 
 func main() {} // Required but unused
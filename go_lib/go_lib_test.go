@@ -0,0 +1,177 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		major, minor, patch int64
+		want                int
+	}{
+		{versionMajor, versionMinor, versionPatch, 1},
+		{0, 0, 0, 1},
+		{versionMajor, versionMinor, versionPatch + 1, 0},
+		{versionMajor, versionMinor + 1, 0, 0},
+		{versionMajor + 1, 0, 0, 0},
+		{versionMajor, versionMinor - 1, 99, 1},
+	}
+	for _, tt := range tests {
+		got := VersionAtLeast(cLongLong(tt.major), cLongLong(tt.minor), cLongLong(tt.patch))
+		if int(got) != tt.want {
+			t.Errorf("VersionAtLeast(%d, %d, %d) = %d, want %d", tt.major, tt.minor, tt.patch, got, tt.want)
+		}
+	}
+}
+
+func TestRequireVersion(t *testing.T) {
+	lockThread(t)
+
+	tests := []struct {
+		major, minor, patch int64
+		want                int
+	}{
+		{versionMajor, versionMinor, versionPatch, ErrOK},
+		{0, 0, 0, ErrOK},
+		{versionMajor, versionMinor, versionPatch + 1, ErrVersionMismatch},
+		{versionMajor, versionMinor + 1, 0, ErrVersionMismatch},
+		{versionMajor + 1, 0, 0, ErrVersionMismatch},
+	}
+	for _, tt := range tests {
+		code := RequireVersion(cLongLong(tt.major), cLongLong(tt.minor), cLongLong(tt.patch))
+		if int(code) != tt.want {
+			t.Errorf("RequireVersion(%d, %d, %d) = %d, want %d", tt.major, tt.minor, tt.patch, code, tt.want)
+		}
+		msg := lastErrorString()
+		if tt.want == ErrOK && msg != "" {
+			t.Errorf("RequireVersion(%d, %d, %d) set last error %q", tt.major, tt.minor, tt.patch, msg)
+		}
+		if tt.want != ErrOK && !strings.Contains(msg, "required") {
+			t.Errorf("RequireVersion(%d, %d, %d) last error = %q", tt.major, tt.minor, tt.patch, msg)
+		}
+	}
+}
+
+func TestGetVersionComponents(t *testing.T) {
+	major, minor, patch := cLongLong(-1), cLongLong(-1), cLongLong(-1)
+	GetVersionComponents(&major, &minor, &patch)
+	if major != versionMajor || minor != versionMinor || patch != versionPatch {
+		t.Errorf("GetVersionComponents = %d.%d.%d, want %d.%d.%d",
+			major, minor, patch, versionMajor, versionMinor, versionPatch)
+	}
+
+	GetVersionComponents(nil, &minor, nil)
+	if minor != versionMinor {
+		t.Errorf("minor = %d, want %d", minor, versionMinor)
+	}
+}
+
+func TestConcatStrings(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{"ascii", "Hello, ", "Go!", "Hello, Go!"},
+		{"empty", "", "", ""},
+		{"multibyte", "héllo ", "漢字 🚀", "héllo 漢字 🚀"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := cString(tt.a), cString(tt.b)
+			defer FreeCString(a)
+			defer FreeCString(b)
+
+			got := ConcatStrings(a, b)
+			defer FreeCString(got)
+
+			if s := goString(got); s != tt.want {
+				t.Errorf("ConcatStrings(%q, %q) = %q, want %q", tt.a, tt.b, s, tt.want)
+			}
+			if s := goString(a); s != tt.a {
+				t.Errorf("input a modified: got %q, want %q", s, tt.a)
+			}
+		})
+	}
+}
+
+func TestConcatStringsNil(t *testing.T) {
+	b := cString("right")
+	defer FreeCString(b)
+
+	got := ConcatStrings(nil, b)
+	defer FreeCString(got)
+	if s := goString(got); s != "right" {
+		t.Errorf("ConcatStrings(nil, %q) = %q, want %q", "right", s, "right")
+	}
+
+	both := ConcatStrings(nil, nil)
+	defer FreeCString(both)
+	if s := goString(both); s != "" {
+		t.Errorf("ConcatStrings(nil, nil) = %q, want empty", s)
+	}
+}
+
+func TestJoinStrings(t *testing.T) {
+	tests := []struct {
+		name  string
+		elems []string
+		sep   string
+		want  string
+	}{
+		{"several", []string{"a", "b", "c"}, ", ", "a, b, c"},
+		{"single", []string{"only"}, "-", "only"},
+		{"empty", nil, ",", ""},
+		{"no separator", []string{"x", "y"}, "", "xy"},
+		{"multibyte", []string{"héllo", "漢字"}, " 🚀 ", "héllo 🚀 漢字"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			arr := cStringArray(tt.elems)
+			defer freeCStringArray(arr, len(tt.elems))
+			sep := cString(tt.sep)
+			defer FreeCString(sep)
+
+			got := JoinStrings(arr, cInt(len(tt.elems)), sep)
+			defer FreeCString(got)
+			if s := goString(got); s != tt.want {
+				t.Errorf("JoinStrings(%q, %q) = %q, want %q", tt.elems, tt.sep, s, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinStringsNilElements(t *testing.T) {
+	arr := cStringArray([]string{"a", "b", "c"})
+	defer freeCStringArray(arr, 3)
+	elems := unsafe.Slice(arr, 3)
+	FreeCString(elems[1])
+	elems[1] = nil
+
+	got := JoinStrings(arr, 3, nil)
+	defer FreeCString(got)
+	if s := goString(got); s != "ac" {
+		t.Errorf("JoinStrings with nil element and separator = %q, want %q", s, "ac")
+	}
+}
+
+func TestJoinStringsInvalid(t *testing.T) {
+	lockThread(t)
+
+	if got := JoinStrings(nil, 2, nil); got != nil {
+		FreeCString(got)
+		t.Error("JoinStrings(nil, 2) should return nil")
+	}
+	if msg := lastErrorString(); !strings.HasPrefix(msg, "JoinStrings:") {
+		t.Errorf("last error = %q, want a JoinStrings error", msg)
+	}
+
+	if got := JoinStrings(nil, -1, nil); got != nil {
+		FreeCString(got)
+		t.Error("JoinStrings with negative count should return nil")
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+
+	"go_lib/internal/buildinfo"
+)
+
+// expectedPrototypes lists the C signature of every export as it must appear
+// in the cgo header, written as "return(param types)". Changing an export's
+// ABI fails this test until the table is updated deliberately.
+var expectedPrototypes = map[string]string{
+	"GetDLLVersion":        "long long int(void)",
+	"GetDLLVersionString":  "char*(void)",
+	"FreeCString":          "void(char*)",
+	"ConcatStrings":        "char*(char*, char*)",
+	"GoFunction":           "void(void)",
+	"AddNumbers":           "long long int(long long int, long long int)",
+	"GetLastError":         "char*(void)",
+	"TriggerPanic":         "int(char*)",
+	"SumArray":             "long long int(long long int*, size_t)",
+	"FillBuffer":           "size_t(unsigned char*, size_t)",
+	"AddFloats":            "double(double, double)",
+	"DivideFloats":         "double(double, double)",
+	"IsNaN":                "int(double)",
+	"RegisterCallback":     "void(uintptr_t)",
+	"TriggerCallback":      "int(long long int)",
+	"IsEven":               "int(long long int)",
+	"ProcessPoint":         "double(Point)",
+	"MakePoint":            "Point(long long int, long long int, double)",
+	"MultiplyNumbers":      "long long int(long long int, long long int)",
+	"ModNumbers":           "long long int(long long int, long long int)",
+	"AddChecked":           "long long int(long long int, long long int, int*)",
+	"DivMod":               "int(long long int, long long int, long long int*, long long int*)",
+	"ProcessJSON":          "char*(char*)",
+	"SetLogCallback":       "void(uintptr_t)",
+	"NewAccumulator":       "uintptr_t(void)",
+	"AccumulatorAdd":       "long long int(uintptr_t, long long int)",
+	"FreeAccumulator":      "int(uintptr_t)",
+	"StartComputation":     "uintptr_t(long long int)",
+	"CancelComputation":    "int(uintptr_t)",
+	"WaitComputation":      "long long int(uintptr_t)",
+	"Utf8Len":              "long long int(char*)",
+	"IsValidUtf8":          "int(char*)",
+	"VersionAtLeast":       "int(long long int, long long int, long long int)",
+	"GetVersionComponents": "void(long long int*, long long int*, long long int*)",
+	"TransformBytes":       "unsigned char*(unsigned char*, size_t, size_t*)",
+	"FreeBytes":            "void(unsigned char*)",
+	"Sha256":               "void(unsigned char*, size_t, unsigned char*)",
+	"LibInit":              "int(void)",
+	"LibShutdown":          "void(void)",
+	"GetMetricsJSON":       "char*(void)",
+	"ResetMetrics":         "void(void)",
+	"JoinStrings":          "char*(char**, int, char*)",
+	"SeedRandom":           "void(long long int)",
+	"NextRandom":           "long long int(void)",
+	"MakeRange":            "long long int*(long long int, long long int, size_t*)",
+	"FreeInt64Array":       "void(long long int*)",
+	"MaybeDouble":          "long long int(long long int, int, int*)",
+	"GetMemStatsJSON":      "char*(void)",
+	"ForceGC":              "void(void)",
+	"AddNumbersBatch":      "void(long long int*, long long int*, long long int*, size_t)",
+	"Utf16ToUtf8":          "char*(short unsigned int*, size_t)",
+	"DivideSafe":           "FfiResult(long long int, long long int)",
+	"FreeResult":           "void(FfiResult)",
+	"StreamRange":          "long long int(long long int, long long int, uintptr_t)",
+	"ListExports":          "char*(void)",
+	"FormatUnixNanos":      "char*(long long int)",
+	"ParseRFC3339":         "long long int(char*)",
+	"ParallelSquare":       "void(long long int*, long long int*, size_t, int)",
+	"SetConfig":            "int(char*, char*)",
+	"GetConfig":            "char*(char*)",
+	"Ping":                 "long long int(long long int)",
+	"Echo":                 "char*(char*)",
+	"Base64Encode":         "char*(unsigned char*, size_t)",
+	"Base64Decode":         "unsigned char*(char*, size_t*)",
+	"ParseInt":             "FfiResult(char*)",
+	"RequireVersion":       "int(long long int, long long int, long long int)",
+	"NoOp":                 "void(void)",
+	"BusyWork":             "long long int(long long int)",
+	"CompareInt":           "int(long long int, long long int)",
+	"ExportState":          "char*(void)",
+	"ImportState":          "int(char*)",
+}
+
+var prototypeLine = regexp.MustCompile(`^extern\s+(.+?)\s*\b(\w+)\((.*)\);$`)
+
+// parsePrototypes extracts the exported function prototypes from a cgo
+// header, keyed by name and normalized to "return(param types)".
+func parsePrototypes(t *testing.T, path string) map[string]string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	protos := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		m := prototypeLine.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if m == nil || strings.HasPrefix(m[2], "_") {
+			continue
+		}
+		var params []string
+		for _, p := range strings.Split(m[3], ",") {
+			p = strings.TrimSpace(p)
+			if p != "void" {
+				// Drop the parameter name, keeping any pointer stars.
+				if i := strings.LastIndexAny(p, " *"); i >= 0 {
+					p = strings.TrimSpace(p[:i+1])
+				}
+			}
+			params = append(params, p)
+		}
+		protos[m[2]] = m[1] + "(" + strings.Join(params, ", ") + ")"
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return protos
+}
+
+func TestHeaderPrototypes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping shared library build in short mode")
+	}
+
+	dir := t.TempDir()
+	lib := filepath.Join(dir, "go_lib"+buildinfo.SharedLibExt(runtime.GOOS))
+	cmd := exec.Command("go", "build", "-buildmode=c-shared", "-o", lib, ".")
+	cmd.Env = append(os.Environ(), "GOPROXY=off", "GOFLAGS=-mod=mod")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if strings.Contains(string(out), "not supported") {
+			t.Skipf("c-shared build mode unavailable: %s", out)
+		}
+		t.Fatalf("go build -buildmode=c-shared: %v\n%s", err, out)
+	}
+
+	protos := parsePrototypes(t, strings.TrimSuffix(lib, filepath.Ext(lib))+".h")
+	for name, want := range expectedPrototypes {
+		got, ok := protos[name]
+		if !ok {
+			t.Errorf("%s: missing from the generated header", name)
+			continue
+		}
+		if got != want {
+			t.Errorf("%s: prototype = %s, want %s", name, got, want)
+		}
+	}
+	for name := range protos {
+		if _, ok := expectedPrototypes[name]; !ok {
+			t.Errorf("%s: exported but not listed in expectedPrototypes", name)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import "C"
+import "time"
+
+// Ping returns token unchanged. A supervisor can call it with a fresh token
+// to confirm the library is loaded and answering.
+//
+//export Ping
+func Ping(token C.longlong) (reply C.longlong) {
+	defer trackCall("Ping", time.Now())
+	recoverToError(func() error {
+		reply = token
+		return nil
+	})
+	return reply
+}
+
+// Echo returns a copy of s that must be released with FreeCString; the
+// input is borrowed and never returned itself. A nil s returns nil and
+// records ErrNullPointer.
+//
+//export Echo
+func Echo(s *C.char) (out *C.char) {
+	defer trackCall("Echo", time.Now())
+	recoverToError(func() error {
+		if s == nil {
+			return newError(ErrNullPointer, "Echo: nil string")
+		}
+		out = allocString(C.GoString(s))
+		return nil
+	})
+	return out
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestPing(t *testing.T) {
+	for _, token := range []int64{0, 1, -1, 42, math.MaxInt64, math.MinInt64} {
+		if got := Ping(cLongLong(token)); int64(got) != token {
+			t.Errorf("Ping(%d) = %d", token, got)
+		}
+	}
+}
+
+func TestEcho(t *testing.T) {
+	for _, s := range []string{"", "ping", "漢字 🚀", strings.Repeat("x", 1<<16)} {
+		in := cString(s)
+		out := Echo(in)
+		if out == nil {
+			t.Fatalf("Echo(%.20q) returned nil", s)
+		}
+		if out == in {
+			t.Errorf("Echo(%.20q) returned the borrowed pointer", s)
+		}
+		FreeCString(in)
+		if got := goString(out); got != s {
+			t.Errorf("Echo(%.20q) = %.20q", s, got)
+		}
+		FreeCString(out)
+	}
+}
+
+func TestEchoNil(t *testing.T) {
+	lockThread(t)
+
+	if out := Echo(nil); out != nil {
+		FreeCString(out)
+		t.Fatal("Echo(nil) should return nil")
+	}
+	if msg := lastErrorString(); msg == "" {
+		t.Error("Echo(nil) should set the last error")
+	}
+}
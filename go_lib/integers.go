@@ -0,0 +1,125 @@
+package main
+
+import "C"
+import (
+	"math"
+	"time"
+)
+
+// IsEven returns 1 if n is even and 0 otherwise. Zero and negative even
+// numbers are even.
+//
+//export IsEven
+func IsEven(n C.longlong) (even C.int) {
+	defer trackCall("IsEven", time.Now())
+	recoverToError(func() error {
+		even = cBool(n%2 == 0)
+		return nil
+	})
+	return even
+}
+
+// MultiplyNumbers returns a * b. On int64 overflow the result wraps around
+// in two's complement, following Go semantics, rather than failing.
+//
+//export MultiplyNumbers
+func MultiplyNumbers(a, b C.longlong) (product C.longlong) {
+	defer trackCall("MultiplyNumbers", time.Now())
+	recoverToError(func() error {
+		product = a * b
+		return nil
+	})
+	return product
+}
+
+// ModNumbers returns a % b, taking the sign of a as Go does. A zero b
+// returns 0 and records ErrInvalidArg as the last error.
+//
+//export ModNumbers
+func ModNumbers(a, b C.longlong) (rem C.longlong) {
+	defer trackCall("ModNumbers", time.Now())
+	recoverToError(func() error {
+		if b == 0 {
+			return newError(ErrInvalidArg, "ModNumbers: division by zero")
+		}
+		rem = a % b
+		return nil
+	})
+	return rem
+}
+
+// AddChecked returns a + b and sets *overflow to 1 if the mathematical sum
+// does not fit in an int64, 0 otherwise. On overflow the returned value is
+// the wrapped sum. A nil overflow pointer is skipped.
+//
+//export AddChecked
+func AddChecked(a, b C.longlong, overflow *C.int) (sum C.longlong) {
+	defer trackCall("AddChecked", time.Now())
+	recoverToError(func() error {
+		// The sum can only overflow when both operands share a sign, and
+		// then it does exactly when b is past the distance from a to the
+		// int64 bound in that direction.
+		over := (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b)
+		sum = a + b
+		if overflow != nil {
+			*overflow = cBool(over)
+		}
+		return nil
+	})
+	return sum
+}
+
+// DivMod writes a / b to *quot and a % b to *rem, truncating toward zero as
+// Go does, and returns ErrOK. A zero b returns ErrInvalidArg and leaves both
+// outputs untouched.
+//
+//export DivMod
+func DivMod(a, b C.longlong, quot *C.longlong, rem *C.longlong) C.int {
+	defer trackCall("DivMod", time.Now())
+	return recoverToError(func() error {
+		if b == 0 {
+			return newError(ErrInvalidArg, "DivMod: division by zero")
+		}
+		if quot != nil {
+			*quot = a / b
+		}
+		if rem != nil {
+			*rem = a % b
+		}
+		return nil
+	})
+}
+
+// MaybeDouble doubles an optional value. A zero hasValue means "no value":
+// it returns 0 and sets *outHasValue to 0. Otherwise it returns value * 2,
+// wrapping on overflow like MultiplyNumbers, and sets *outHasValue to 1. A
+// nil outHasValue is skipped.
+//
+//export MaybeDouble
+func MaybeDouble(value C.longlong, hasValue C.int, outHasValue *C.int) (doubled C.longlong) {
+	defer trackCall("MaybeDouble", time.Now())
+	recoverToError(func() error {
+		if outHasValue != nil {
+			*outHasValue = cBool(hasValue != 0)
+		}
+		if hasValue != 0 {
+			doubled = value * 2
+		}
+		return nil
+	})
+	return doubled
+}
+
+// CompareInt returns -1 if a < b, 0 if a == b and 1 if a > b, and never any
+// other value. It compares rather than subtracts, so extremes such as
+// MinInt64 against MaxInt64 cannot overflow.
+//
+//export CompareInt
+func CompareInt(a, b C.longlong) (order C.int) {
+	defer trackCall("CompareInt", time.Now())
+	recoverToError(func() error {
+		order = cBool(a > b) - cBool(a < b)
+		return nil
+	})
+	return order
+}
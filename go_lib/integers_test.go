@@ -0,0 +1,179 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIsEven(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want int
+	}{
+		{0, 1},
+		{1, 0},
+		{2, 1},
+		{-1, 0},
+		{-2, 1},
+		{-7, 0},
+		{math.MaxInt64, 0},
+		{math.MinInt64, 1},
+	}
+	for _, tt := range tests {
+		got := IsEven(cLongLong(tt.n))
+		if got != 0 && got != 1 {
+			t.Fatalf("IsEven(%d) = %d, outside {0,1}", tt.n, got)
+		}
+		if int(got) != tt.want {
+			t.Errorf("IsEven(%d) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestMultiplyNumbers(t *testing.T) {
+	tests := []struct {
+		a, b, want int64
+	}{
+		{6, 7, 42},
+		{-6, 7, -42},
+		{-6, -7, 42},
+		{math.MaxInt64, 2, -2},
+		{math.MinInt64, -1, math.MinInt64},
+	}
+	for _, tt := range tests {
+		if got := MultiplyNumbers(cLongLong(tt.a), cLongLong(tt.b)); int64(got) != tt.want {
+			t.Errorf("MultiplyNumbers(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestModNumbers(t *testing.T) {
+	lockThread(t)
+
+	tests := []struct {
+		a, b, want int64
+	}{
+		{7, 3, 1},
+		{-7, 3, -1},
+		{7, -3, 1},
+		{math.MinInt64, -1, 0},
+	}
+	for _, tt := range tests {
+		if got := ModNumbers(cLongLong(tt.a), cLongLong(tt.b)); int64(got) != tt.want {
+			t.Errorf("ModNumbers(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	if got := ModNumbers(7, 0); got != 0 {
+		t.Errorf("ModNumbers(7, 0) = %d, want 0", got)
+	}
+	if msg := lastErrorString(); msg == "" {
+		t.Error("ModNumbers(7, 0) should set the last error")
+	}
+}
+
+func TestAddChecked(t *testing.T) {
+	tests := []struct {
+		a, b     int64
+		want     int64
+		overflow int
+	}{
+		{2, 3, 5, 0},
+		{-2, -3, -5, 0},
+		{math.MaxInt64, 0, math.MaxInt64, 0},
+		{math.MaxInt64, -1, math.MaxInt64 - 1, 0},
+		{math.MaxInt64, 1, math.MinInt64, 1},
+		{math.MinInt64, -1, math.MaxInt64, 1},
+		{math.MinInt64, math.MaxInt64, -1, 0},
+	}
+	for _, tt := range tests {
+		overflow := cInt(-1)
+		got := AddChecked(cLongLong(tt.a), cLongLong(tt.b), &overflow)
+		if int64(got) != tt.want || int(overflow) != tt.overflow {
+			t.Errorf("AddChecked(%d, %d) = %d, overflow %d; want %d, overflow %d",
+				tt.a, tt.b, got, overflow, tt.want, tt.overflow)
+		}
+	}
+
+	if got := AddChecked(math.MaxInt64, 1, nil); got != math.MinInt64 {
+		t.Errorf("AddChecked with nil overflow = %d, want %d", got, int64(math.MinInt64))
+	}
+}
+
+func TestDivMod(t *testing.T) {
+	tests := []struct {
+		a, b, quot, rem int64
+	}{
+		{7, 2, 3, 1},
+		{-7, 2, -3, -1},
+		{7, -2, -3, 1},
+		{math.MinInt64, -1, math.MinInt64, 0},
+	}
+	for _, tt := range tests {
+		quot, rem := cLongLong(99), cLongLong(99)
+		if code := DivMod(cLongLong(tt.a), cLongLong(tt.b), &quot, &rem); code != ErrOK {
+			t.Fatalf("DivMod(%d, %d) = %d, want %d", tt.a, tt.b, code, ErrOK)
+		}
+		if int64(quot) != tt.quot || int64(rem) != tt.rem {
+			t.Errorf("DivMod(%d, %d) = (%d, %d), want (%d, %d)", tt.a, tt.b, quot, rem, tt.quot, tt.rem)
+		}
+	}
+}
+
+func TestDivModNilAndZero(t *testing.T) {
+	rem := cLongLong(0)
+	if code := DivMod(9, 4, nil, &rem); code != ErrOK || rem != 1 {
+		t.Errorf("DivMod(9, 4, nil, &rem) = %d, rem %d; want %d, rem 1", code, rem, ErrOK)
+	}
+
+	quot, rem := cLongLong(99), cLongLong(99)
+	if code := DivMod(1, 0, &quot, &rem); code != ErrInvalidArg {
+		t.Errorf("DivMod(1, 0) = %d, want %d", code, ErrInvalidArg)
+	}
+	if quot != 99 || rem != 99 {
+		t.Errorf("DivMod(1, 0) wrote outputs (%d, %d)", quot, rem)
+	}
+}
+
+func TestMaybeDouble(t *testing.T) {
+	outHas := cInt(99)
+	if got := MaybeDouble(21, 1, &outHas); got != 42 || outHas != 1 {
+		t.Errorf("MaybeDouble(Some(21)) = %d, has %d; want 42, 1", got, outHas)
+	}
+
+	outHas = 99
+	if got := MaybeDouble(21, 0, &outHas); got != 0 || outHas != 0 {
+		t.Errorf("MaybeDouble(None) = %d, has %d; want 0, 0", got, outHas)
+	}
+
+	outHas = 99
+	if got := MaybeDouble(-5, 7, &outHas); got != -10 || outHas != 1 {
+		t.Errorf("MaybeDouble with hasValue 7 = %d, has %d; want -10, 1", got, outHas)
+	}
+
+	if got := MaybeDouble(3, 1, nil); got != 6 {
+		t.Errorf("MaybeDouble with nil outHasValue = %d, want 6", got)
+	}
+}
+
+func TestCompareInt(t *testing.T) {
+	tests := []struct {
+		a, b int64
+		want int
+	}{
+		{1, 1, 0},
+		{1, 2, -1},
+		{2, 1, 1},
+		{-5, 5, -1},
+		{math.MinInt64, math.MaxInt64, -1},
+		{math.MaxInt64, math.MinInt64, 1},
+		{math.MinInt64, math.MinInt64, 0},
+		{math.MaxInt64, -1, 1},
+		{math.MinInt64, 1, -1},
+	}
+	for _, tt := range tests {
+		if got := CompareInt(cLongLong(tt.a), cLongLong(tt.b)); int(got) != tt.want {
+			t.Errorf("CompareInt(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Package buildinfo holds the artifact naming rules shared by the build
+// tools, so that build.go and cmd/buildall agree on file names.
+package buildinfo
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+)
+
+// LibName is the base name of the shared library.
+const LibName = "go_lib"
+
+// SharedLibExt returns the shared library extension used on goos.
+func SharedLibExt(goos string) string {
+	switch goos {
+	case "windows":
+		return ".dll"
+	case "darwin", "ios":
+		return ".dylib"
+	default:
+		return ".so"
+	}
+}
+
+// Version parses the versionMajor/Minor/Patch constants out of the Go file
+// at path, so artifact names always match what GetDLLVersion reports.
+func Version(path string) (string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return "", err
+	}
+
+	parts := map[string]int{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		vs, ok := n.(*ast.ValueSpec)
+		if !ok {
+			return true
+		}
+		for i, name := range vs.Names {
+			switch name.Name {
+			case "versionMajor", "versionMinor", "versionPatch":
+			default:
+				continue
+			}
+			if i >= len(vs.Values) {
+				continue
+			}
+			if lit, ok := vs.Values[i].(*ast.BasicLit); ok && lit.Kind == token.INT {
+				if v, err := strconv.Atoi(lit.Value); err == nil {
+					parts[name.Name] = v
+				}
+			}
+		}
+		return true
+	})
+
+	for _, name := range []string{"versionMajor", "versionMinor", "versionPatch"} {
+		if _, ok := parts[name]; !ok {
+			return "", fmt.Errorf("%s: %s must be an integer literal constant", path, name)
+		}
+	}
+	return fmt.Sprintf("%d.%d.%d", parts["versionMajor"], parts["versionMinor"], parts["versionPatch"]), nil
+}
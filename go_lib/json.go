@@ -0,0 +1,43 @@
+package main
+
+import "C"
+import (
+	"encoding/json"
+	"time"
+)
+
+// jsonPayload is the document accepted by ProcessJSON.
+type jsonPayload struct {
+	A   int64 `json:"a"`
+	B   int64 `json:"b"`
+	Sum int64 `json:"sum"`
+}
+
+// ProcessJSON decodes a {"a": ..., "b": ...} document, fills in the computed
+// "sum" field and returns the re-encoded document. The result must be
+// released with FreeCString. Invalid JSON returns nil and records
+// ErrInvalidArg as the last error.
+//
+//export ProcessJSON
+func ProcessJSON(input *C.char) (output *C.char) {
+	defer trackCall("ProcessJSON", time.Now())
+	recoverToError(func() error {
+		if input == nil {
+			return newError(ErrNullPointer, "ProcessJSON: nil input")
+		}
+
+		var payload jsonPayload
+		if err := json.Unmarshal([]byte(C.GoString(input)), &payload); err != nil {
+			return newError(ErrInvalidArg, "ProcessJSON: %v", err)
+		}
+		payload.Sum = payload.A + payload.B
+
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		output = allocString(string(encoded))
+		return nil
+	})
+	return output
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProcessJSON(t *testing.T) {
+	input := cString(`{"a":1,"b":2}`)
+	defer FreeCString(input)
+
+	output := ProcessJSON(input)
+	if output == nil {
+		t.Fatal("ProcessJSON returned nil for valid input")
+	}
+	defer FreeCString(output)
+
+	if got, want := goString(output), `{"a":1,"b":2,"sum":3}`; got != want {
+		t.Errorf("ProcessJSON = %s, want %s", got, want)
+	}
+}
+
+func TestProcessJSONInvalid(t *testing.T) {
+	lockThread(t)
+
+	input := cString(`{"a":1,`)
+	defer FreeCString(input)
+
+	if output := ProcessJSON(input); output != nil {
+		FreeCString(output)
+		t.Fatal("ProcessJSON should return nil for invalid JSON")
+	}
+	if msg := lastErrorString(); !strings.HasPrefix(msg, "ProcessJSON:") {
+		t.Errorf("last error = %q, want a ProcessJSON error", msg)
+	}
+
+	if output := ProcessJSON(nil); output != nil {
+		FreeCString(output)
+		t.Fatal("ProcessJSON should return nil for nil input")
+	}
+}
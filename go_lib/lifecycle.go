@@ -0,0 +1,66 @@
+package main
+
+import "C"
+import (
+	"os"
+	"time"
+)
+
+// The library needs no initialization: its state is ready from load and
+// LibShutdown returns it to that starting point. LibInit is kept so hosts
+// written against the LibInit/LibShutdown pair keep working.
+
+// LibInit does nothing and returns ErrOK. It is safe to call any number of
+// times, before or after LibShutdown.
+//
+//export LibInit
+func LibInit() C.int {
+	defer trackCall("LibInit", time.Now())
+	return recoverToError(func() error {
+		return nil
+	})
+}
+
+// LibShutdown cancels and waits for outstanding computations, frees every
+// accumulator handle, removes the registered callbacks, clears the last
+// errors, call metrics and config, reseeds the random generator and flushes
+// stdout. It is safe to call more than once.
+//
+//export LibShutdown
+func LibShutdown() {
+	defer trackCall("LibShutdown", time.Now())
+	recoverToError(func() error {
+		computations.Range(func(key, value any) bool {
+			c := value.(*computation)
+			c.cancel()
+			<-c.done
+			computations.Delete(key)
+			return true
+		})
+		accumulators.Range(func(key, _ any) bool {
+			accumulators.Delete(key)
+			return true
+		})
+
+		callbackMu.Lock()
+		callback = 0
+		callbackMu.Unlock()
+		logCallbackMu.Lock()
+		logCallback = 0
+		logCallbackMu.Unlock()
+
+		lastErrorMu.Lock()
+		clear(lastErrors)
+		lastErrorMu.Unlock()
+		resetMetrics()
+		configMu.Lock()
+		clear(config)
+		configMu.Unlock()
+		rngMu.Lock()
+		seedRNG(defaultSeed)
+		rngMu.Unlock()
+
+		os.Stdout.Sync()
+		return nil
+	})
+}
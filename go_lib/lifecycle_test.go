@@ -0,0 +1,44 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLifecycle(t *testing.T) {
+	if code := LibInit(); code != ErrOK {
+		t.Fatalf("LibInit = %d, want %d", code, ErrOK)
+	}
+	if code := LibInit(); code != ErrOK {
+		t.Fatalf("second LibInit = %d, want %d", code, ErrOK)
+	}
+
+	acc := NewAccumulator()
+	AccumulatorAdd(acc, 3)
+	comp := StartComputation(math.MaxInt64)
+	RegisterCallback(recordingCallback())
+	setConfig(t, "test.shutdown", "on")
+
+	LibShutdown()
+	LibShutdown()
+
+	if code := FreeAccumulator(acc); code != ErrInvalidArg {
+		t.Errorf("accumulator survived shutdown: FreeAccumulator = %d", code)
+	}
+	if got := WaitComputation(comp); got != -1 {
+		t.Errorf("computation survived shutdown: WaitComputation = %d", got)
+	}
+	if code := TriggerCallback(1); code != ErrNullPointer {
+		t.Errorf("callback survived shutdown: TriggerCallback = %d", code)
+	}
+	if _, ok := getConfig("test.shutdown"); ok {
+		t.Error("config survived shutdown")
+	}
+}
+
+func TestUseWithoutInit(t *testing.T) {
+	LibShutdown()
+	if got := AddNumbers(2, 3); got != 5 {
+		t.Errorf("AddNumbers after LibShutdown without LibInit = %d, want 5", got)
+	}
+}
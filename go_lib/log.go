@@ -0,0 +1,44 @@
+package main
+
+// #include <stdint.h>
+import "C"
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	logCallbackMu sync.RWMutex
+	logCallback   uintptr
+)
+
+// SetLogCallback installs a C function of type void (*)(const char *) that
+// receives the library's informational messages instead of stdout. The
+// message is owned by Go and freed once the callback returns, so the host
+// must copy it if it needs to keep it. Passing 0 restores stdout.
+//
+//export SetLogCallback
+func SetLogCallback(cb C.uintptr_t) {
+	defer trackCall("SetLogCallback", time.Now())
+	recoverToError(func() error {
+		logCallbackMu.Lock()
+		logCallback = uintptr(cb)
+		logCallbackMu.Unlock()
+		return nil
+	})
+}
+
+// logInfo sends msg to the installed log callback, or prints it to stdout
+// if there is none.
+func logInfo(msg string) {
+	logCallbackMu.RLock()
+	cb := logCallback
+	logCallbackMu.RUnlock()
+
+	if cb == 0 {
+		fmt.Println(msg)
+		return
+	}
+	invokeLogCallback(cb, msg)
+}
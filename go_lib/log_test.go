@@ -0,0 +1,13 @@
+package main
+
+import "testing"
+
+func TestSetLogCallback(t *testing.T) {
+	SetLogCallback(recordingLogCallback())
+	defer SetLogCallback(0)
+
+	GoFunction()
+	if got := recordedMessage(); got != "Hello from Go!" {
+		t.Errorf("captured message = %q, want %q", got, "Hello from Go!")
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import "C"
+import (
+	"encoding/json"
+	"runtime"
+	"time"
+)
+
+type memStats struct {
+	HeapAlloc    uint64 `json:"HeapAlloc"`
+	HeapSys      uint64 `json:"HeapSys"`
+	NumGC        uint32 `json:"NumGC"`
+	PauseTotalNs uint64 `json:"PauseTotalNs"`
+}
+
+// GetMemStatsJSON returns a snapshot of the Go heap as
+// {"HeapAlloc":…,"HeapSys":…,"NumGC":…,"PauseTotalNs":…}, using the field
+// meanings of runtime.MemStats. ReadMemStats briefly stops the world, so
+// avoid calling it on a hot path. The result must be released with
+// FreeCString.
+//
+//export GetMemStatsJSON
+func GetMemStatsJSON() (out *C.char) {
+	defer trackCall("GetMemStatsJSON", time.Now())
+	recoverToError(func() error {
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+		encoded, err := json.Marshal(memStats{
+			HeapAlloc:    m.HeapAlloc,
+			HeapSys:      m.HeapSys,
+			NumGC:        m.NumGC,
+			PauseTotalNs: m.PauseTotalNs,
+		})
+		if err != nil {
+			return err
+		}
+		out = allocString(string(encoded))
+		return nil
+	})
+	return out
+}
+
+// ForceGC runs a full Go garbage collection and blocks until it completes.
+// It is meant for tests and benchmarks.
+//
+//export ForceGC
+func ForceGC() {
+	defer trackCall("ForceGC", time.Now())
+	recoverToError(func() error {
+		runtime.GC()
+		return nil
+	})
+}
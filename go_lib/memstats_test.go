@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+var garbageSink [][]byte
+
+func readMemStats(t *testing.T) memStats {
+	t.Helper()
+	out := GetMemStatsJSON()
+	if out == nil {
+		t.Fatal("GetMemStatsJSON returned nil")
+	}
+	defer FreeCString(out)
+
+	var m memStats
+	if err := json.Unmarshal([]byte(goString(out)), &m); err != nil {
+		t.Fatalf("GetMemStatsJSON returned invalid JSON %q: %v", goString(out), err)
+	}
+	return m
+}
+
+func TestForceGC(t *testing.T) {
+	before := readMemStats(t)
+	if before.HeapSys == 0 || before.HeapAlloc == 0 {
+		t.Errorf("implausible heap stats %+v", before)
+	}
+
+	for i := 0; i < 1000; i++ {
+		garbageSink = append(garbageSink, make([]byte, 1024))
+	}
+	garbageSink = nil
+	ForceGC()
+
+	after := readMemStats(t)
+	if after.NumGC <= before.NumGC {
+		t.Errorf("NumGC = %d after ForceGC, want more than %d", after.NumGC, before.NumGC)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import "C"
+import (
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Every export except GetMetricsJSON, ResetMetrics and NoOp starts with
+//
+//	defer trackCall("Name", time.Now())
+//
+// which costs a read-locked map lookup and two atomic adds per call. Exports
+// must not call each other, or the callee's count includes calls the host
+// never made; shared logic goes in an unexported helper.
+
+type callMetrics struct {
+	calls   atomic.Int64
+	totalNs atomic.Int64
+}
+
+var (
+	metricsMu sync.RWMutex
+	metrics   = map[string]*callMetrics{}
+)
+
+func metricsFor(name string) *callMetrics {
+	metricsMu.RLock()
+	m := metrics[name]
+	metricsMu.RUnlock()
+	if m != nil {
+		return m
+	}
+
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+	if m = metrics[name]; m == nil {
+		m = &callMetrics{}
+		metrics[name] = m
+	}
+	return m
+}
+
+// trackCall records one call to the named export that started at start.
+func trackCall(name string, start time.Time) {
+	m := metricsFor(name)
+	m.calls.Add(1)
+	m.totalNs.Add(int64(time.Since(start)))
+}
+
+func resetMetrics() {
+	metricsMu.Lock()
+	clear(metrics)
+	metricsMu.Unlock()
+}
+
+type metricsSnapshot struct {
+	Calls   int64 `json:"calls"`
+	TotalNs int64 `json:"total_ns"`
+}
+
+// GetMetricsJSON returns the call count and cumulative time spent in Go for
+// every export called since the last reset, as
+// {"AddNumbers":{"calls":42,"total_ns":1234}}. The result must be released
+// with FreeCString.
+//
+//export GetMetricsJSON
+func GetMetricsJSON() (out *C.char) {
+	recoverToError(func() error {
+		snapshot := map[string]metricsSnapshot{}
+		metricsMu.RLock()
+		for name, m := range metrics {
+			snapshot[name] = metricsSnapshot{Calls: m.calls.Load(), TotalNs: m.totalNs.Load()}
+		}
+		metricsMu.RUnlock()
+
+		encoded, err := json.Marshal(snapshot)
+		if err != nil {
+			return err
+		}
+		out = allocString(string(encoded))
+		return nil
+	})
+	return out
+}
+
+// ResetMetrics discards all recorded call metrics.
+//
+//export ResetMetrics
+func ResetMetrics() {
+	recoverToError(func() error {
+		resetMetrics()
+		return nil
+	})
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func metricsSnapshotMap(t *testing.T) map[string]metricsSnapshot {
+	t.Helper()
+	out := GetMetricsJSON()
+	if out == nil {
+		t.Fatal("GetMetricsJSON returned nil")
+	}
+	defer FreeCString(out)
+
+	var got map[string]metricsSnapshot
+	if err := json.Unmarshal([]byte(goString(out)), &got); err != nil {
+		t.Fatalf("GetMetricsJSON returned invalid JSON %q: %v", goString(out), err)
+	}
+	return got
+}
+
+func TestMetricsCounts(t *testing.T) {
+	ResetMetrics()
+	for i := 0; i < 5; i++ {
+		AddNumbers(cLongLong(int64(i)), 1)
+	}
+	for i := 0; i < 3; i++ {
+		IsEven(cLongLong(int64(i)))
+	}
+
+	got := metricsSnapshotMap(t)
+	if calls := got["AddNumbers"].Calls; calls != 5 {
+		t.Errorf("AddNumbers calls = %d, want 5", calls)
+	}
+	if calls := got["IsEven"].Calls; calls != 3 {
+		t.Errorf("IsEven calls = %d, want 3", calls)
+	}
+	if ns := got["AddNumbers"].TotalNs; ns <= 0 {
+		t.Errorf("AddNumbers total_ns = %d, want > 0", ns)
+	}
+	if _, ok := got["MultiplyNumbers"]; ok {
+		t.Error("uncalled export MultiplyNumbers reported")
+	}
+
+	ResetMetrics()
+	GetVersionComponents(nil, nil, nil)
+	got = metricsSnapshotMap(t)
+	if calls := got["GetVersionComponents"].Calls; calls != 1 {
+		t.Errorf("GetVersionComponents calls = %d, want 1", calls)
+	}
+	if _, ok := got["GetDLLVersion"]; ok {
+		t.Error("GetVersionComponents reported a GetDLLVersion call")
+	}
+}
+
+func TestResetMetrics(t *testing.T) {
+	AddNumbers(1, 2)
+	ResetMetrics()
+	if got := metricsSnapshotMap(t); len(got) != 0 {
+		t.Errorf("metrics after ResetMetrics = %v, want none", got)
+	}
+}
+
+// TestExportsTrackCalls makes sure no export forgets to record its metrics.
+func TestExportsTrackCalls(t *testing.T) {
+	untracked := map[string]bool{"GetMetricsJSON": true, "ResetMetrics": true, "NoOp": true}
+
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	var exports []*ast.FuncDecl
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		if ok, err := build.Default.MatchFile(".", path); err != nil || !ok {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && isExportDecl(fn) {
+				exports = append(exports, fn)
+			}
+		}
+	}
+	if len(exports) != len(exportSignatures) {
+		t.Fatalf("found %d exports, registry lists %d", len(exports), len(exportSignatures))
+	}
+
+	isExport := make(map[string]bool, len(exports))
+	for _, fn := range exports {
+		isExport[fn.Name.Name] = true
+	}
+	for _, fn := range exports {
+		if !untracked[fn.Name.Name] && !tracksCall(fn) {
+			t.Errorf("%s: export %s does not start with defer trackCall(%q, time.Now())",
+				fset.Position(fn.Pos()), fn.Name.Name, fn.Name.Name)
+		}
+		// An export calling another export records a call the host never
+		// made; shared logic belongs in an unexported helper.
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			if ident, ok := call.Fun.(*ast.Ident); ok && isExport[ident.Name] {
+				t.Errorf("%s: export %s calls export %s",
+					fset.Position(call.Pos()), fn.Name.Name, ident.Name)
+			}
+			return true
+		})
+	}
+}
+
+// isExportDecl reports whether fn carries an //export directive. The
+// directive is read from the raw comments because CommentGroup.Text drops it.
+func isExportDecl(fn *ast.FuncDecl) bool {
+	if fn.Doc == nil {
+		return false
+	}
+	for _, c := range fn.Doc.List {
+		if c.Text == "//export "+fn.Name.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func tracksCall(fn *ast.FuncDecl) bool {
+	if len(fn.Body.List) == 0 {
+		return false
+	}
+	stmt, ok := fn.Body.List[0].(*ast.DeferStmt)
+	if !ok {
+		return false
+	}
+	if ident, ok := stmt.Call.Fun.(*ast.Ident); !ok || ident.Name != "trackCall" {
+		return false
+	}
+	lit, ok := stmt.Call.Args[0].(*ast.BasicLit)
+	if !ok {
+		return false
+	}
+	name, err := strconv.Unquote(lit.Value)
+	return err == nil && name == fn.Name.Name
+}
@@ -0,0 +1,63 @@
+package main
+
+import "C"
+import (
+	"runtime"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// squareChunk is how many elements a ParallelSquare worker takes per receive,
+// so the channel is not touched once per element.
+const squareChunk = 4096
+
+// ParallelSquare writes in[i] * in[i] to out[i] for each of the n elements,
+// wrapping on overflow, using a pool of workers goroutines fed chunk offsets
+// over a channel. Each chunk is written by exactly one worker, so the writes
+// never overlap. workers is clamped to [1, runtime.NumCPU()]. Both arrays
+// are borrowed and must not overlap; the call returns once every element is
+// written. A zero n does nothing; a nil pointer with a nonzero n writes
+// nothing and records ErrNullPointer.
+//
+//export ParallelSquare
+func ParallelSquare(in *C.longlong, out *C.longlong, n C.size_t, workers C.int) {
+	defer trackCall("ParallelSquare", time.Now())
+	recoverToError(func() error {
+		if n == 0 {
+			return nil
+		}
+		if in == nil || out == nil {
+			return newError(ErrNullPointer, "ParallelSquare: nil pointer with length %d", n)
+		}
+		src, dst := unsafe.Slice(in, n), unsafe.Slice(out, n)
+		parallelSquare(src, dst, clampWorkers(int(workers)))
+		return nil
+	})
+}
+
+func clampWorkers(workers int) int {
+	return max(1, min(workers, runtime.NumCPU()))
+}
+
+func parallelSquare(src, dst []C.longlong, workers int) {
+	chunks := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range chunks {
+				end := min(start+squareChunk, len(src))
+				for i := start; i < end; i++ {
+					dst[i] = src[i] * src[i]
+				}
+			}
+		}()
+	}
+	for start := 0; start < len(src); start += squareChunk {
+		chunks <- start
+	}
+	close(chunks)
+	wg.Wait()
+}
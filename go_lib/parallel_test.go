@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParallelSquare(t *testing.T) {
+	const n = 3*squareChunk + 17
+	vals := make([]int64, n)
+	for i := range vals {
+		vals[i] = int64(i - n/2)
+	}
+	in := cLongLongs(vals)
+	defer cFree(in)
+	out := cLongLongs(make([]int64, n))
+	defer cFree(out)
+
+	for _, workers := range []int{-3, 0, 1, 4, 1 << 20} {
+		ParallelSquare(in, out, n, cInt(workers))
+		got := goLongLongs(out, n)
+		for i, v := range vals {
+			if got[i] != v*v {
+				t.Fatalf("workers=%d: out[%d] = %d, want %d", workers, i, got[i], v*v)
+			}
+		}
+	}
+}
+
+func TestParallelSquareEmptyAndNil(t *testing.T) {
+	lockThread(t)
+
+	ParallelSquare(nil, nil, 0, 4)
+	if msg := lastErrorString(); msg != "" {
+		t.Errorf("zero length should not set an error, got %q", msg)
+	}
+
+	in := cLongLongs([]int64{2})
+	defer cFree(in)
+	ParallelSquare(in, nil, 1, 4)
+	if msg := lastErrorString(); !strings.HasPrefix(msg, "ParallelSquare:") {
+		t.Errorf("last error = %q, want a ParallelSquare error", msg)
+	}
+}
+
+func TestClampWorkers(t *testing.T) {
+	cpus := runtime.NumCPU()
+	for _, tt := range []struct{ in, want int }{
+		{-1, 1}, {0, 1}, {1, 1}, {cpus, cpus}, {cpus + 1, cpus},
+	} {
+		if got := clampWorkers(tt.in); got != tt.want {
+			t.Errorf("clampWorkers(%d) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func BenchmarkParallelSquare(b *testing.B) {
+	const n = 1 << 22
+	in := cLongLongs(make([]int64, n))
+	defer cFree(in)
+	out := cLongLongs(make([]int64, n))
+	defer cFree(out)
+
+	// Counts above runtime.NumCPU() are clamped and measure the same pool.
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.SetBytes(n * 8)
+			for i := 0; i < b.N; i++ {
+				ParallelSquare(in, out, n, cInt(workers))
+			}
+		})
+	}
+}
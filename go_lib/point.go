@@ -0,0 +1,46 @@
+package main
+
+/*
+// Point is passed by value between the host and Go. Its layout must match
+// the #[repr(C)] struct on the Rust side: x at offset 0, y at 8, weight at
+// 16, 24 bytes in total with no padding. Pointer fields are not supported,
+// since Go cannot keep foreign memory reachable from inside a struct.
+typedef struct {
+	long long x;
+	long long y;
+	double weight;
+} Point;
+*/
+import "C"
+import "time"
+
+func newPoint(x, y int64, weight float64) C.Point {
+	return C.Point{x: C.longlong(x), y: C.longlong(y), weight: C.double(weight)}
+}
+
+// ProcessPoint returns (x + y) * weight.
+//
+//export ProcessPoint
+func ProcessPoint(p C.Point) (result C.double) {
+	defer trackCall("ProcessPoint", time.Now())
+	recoverToError(func() error {
+		result = C.double(p.x+p.y) * p.weight
+		return nil
+	})
+	return result
+}
+
+// MakePoint returns a Point by value. Struct returns go through the
+// platform's C ABI (a hidden return pointer on both the SysV and Windows x64
+// conventions for a 24-byte struct), so the fields are covered by tests on
+// each platform.
+//
+//export MakePoint
+func MakePoint(x, y C.longlong, w C.double) (p C.Point) {
+	defer trackCall("MakePoint", time.Now())
+	recoverToError(func() error {
+		p = C.Point{x: x, y: y, weight: w}
+		return nil
+	})
+	return p
+}
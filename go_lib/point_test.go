@@ -0,0 +1,54 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"unsafe"
+)
+
+func TestPointLayout(t *testing.T) {
+	p := newPoint(0, 0, 0)
+	if got := unsafe.Sizeof(p); got != 24 {
+		t.Errorf("sizeof(Point) = %d, want 24", got)
+	}
+	if got := unsafe.Alignof(p); got != 8 {
+		t.Errorf("alignof(Point) = %d, want 8", got)
+	}
+
+	offsets := []struct {
+		field string
+		got   uintptr
+		want  uintptr
+	}{
+		{"x", unsafe.Offsetof(p.x), 0},
+		{"y", unsafe.Offsetof(p.y), 8},
+		{"weight", unsafe.Offsetof(p.weight), 16},
+	}
+	for _, o := range offsets {
+		if o.got != o.want {
+			t.Errorf("offsetof(Point.%s) = %d, want %d", o.field, o.got, o.want)
+		}
+	}
+}
+
+func TestProcessPoint(t *testing.T) {
+	if got := ProcessPoint(newPoint(2, 3, 1.5)); got != 7.5 {
+		t.Errorf("ProcessPoint(2, 3, 1.5) = %v, want 7.5", got)
+	}
+	if got := ProcessPoint(newPoint(-4, 1, -2)); got != 6 {
+		t.Errorf("ProcessPoint(-4, 1, -2) = %v, want 6", got)
+	}
+}
+
+func TestMakePoint(t *testing.T) {
+	weights := []float64{0, math.Copysign(0, -1), 0.1, 1.5, -2.75, math.MaxFloat64, math.SmallestNonzeroFloat64}
+	for _, w := range weights {
+		p := MakePoint(cLongLong(math.MaxInt64), cLongLong(math.MinInt64), cDouble(w))
+		if p.x != math.MaxInt64 || p.y != math.MinInt64 {
+			t.Errorf("MakePoint coordinates = (%d, %d), want (%d, %d)", p.x, p.y, int64(math.MaxInt64), int64(math.MinInt64))
+		}
+		if got := math.Float64bits(float64(p.weight)); got != math.Float64bits(w) {
+			t.Errorf("MakePoint weight bits = %#x, want %#x (%v)", got, math.Float64bits(w), w)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import "C"
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// defaultSeed is the seed the generator starts from and LibShutdown
+// restores.
+const defaultSeed = 1
+
+// rngSource is kept beside rng so ExportState can marshal the generator's
+// state and ImportState restore it in one step.
+var (
+	rngMu     sync.Mutex
+	rngSource = rand.NewPCG(defaultSeed, 0)
+	rng       = rand.New(rngSource)
+)
+
+// seedRNG reseeds the generator; rngMu must be held.
+func seedRNG(seed int64) {
+	rngSource.Seed(uint64(seed), 0)
+}
+
+// SeedRandom resets the library's pseudo-random generator to seed. The same
+// seed always produces the same NextRandom sequence.
+//
+//export SeedRandom
+func SeedRandom(seed C.longlong) {
+	defer trackCall("SeedRandom", time.Now())
+	recoverToError(func() error {
+		rngMu.Lock()
+		seedRNG(int64(seed))
+		rngMu.Unlock()
+		return nil
+	})
+}
+
+// NextRandom returns the next non-negative pseudo-random value from the
+// generator seeded by SeedRandom.
+//
+//export NextRandom
+func NextRandom() (n C.longlong) {
+	defer trackCall("NextRandom", time.Now())
+	recoverToError(func() error {
+		rngMu.Lock()
+		n = C.longlong(rng.Int64())
+		rngMu.Unlock()
+		return nil
+	})
+	return n
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+func randomSequence(n int) []int64 {
+	seq := make([]int64, n)
+	for i := range seq {
+		seq[i] = int64(NextRandom())
+	}
+	return seq
+}
+
+func TestSeedRandomRepeats(t *testing.T) {
+	SeedRandom(42)
+	first := randomSequence(16)
+	SeedRandom(42)
+	second := randomSequence(16)
+
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("value %d after reseeding = %d, want %d", i, second[i], first[i])
+		}
+		if first[i] < 0 {
+			t.Errorf("NextRandom returned negative value %d", first[i])
+		}
+	}
+
+	SeedRandom(43)
+	if other := randomSequence(16); other[0] == first[0] && other[1] == first[1] {
+		t.Errorf("seeds 42 and 43 produced the same sequence start %v", other[:2])
+	}
+}
+
+func TestNextRandomConcurrent(t *testing.T) {
+	SeedRandom(7)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			randomSequence(1000)
+		}()
+	}
+	wg.Wait()
+}
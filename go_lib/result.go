@@ -0,0 +1,96 @@
+package main
+
+/*
+#include <stdlib.h>
+
+// FfiResult carries an error code, its message and the value of a call in
+// one return. message is NULL on success; otherwise it is allocated by Go and
+// released with FreeResult. value is only meaningful when code is 0. The
+// Rust side declares the same #[repr(C)] layout through bindgen.
+typedef struct {
+	int code;
+	char *message;
+	long long value;
+} FfiResult;
+*/
+import "C"
+import (
+	"strconv"
+	"time"
+	"unsafe"
+)
+
+// resultFromError converts err into an FfiResult whose code is picked as
+// recoverToError would and whose message is a copy of err's text. A nil err
+// gives ErrOK with a nil message; the caller then fills in the value.
+func resultFromError(err error) (r C.FfiResult) {
+	r.code = errorCode(err)
+	if err != nil {
+		r.message = allocString(err.Error())
+	}
+	return r
+}
+
+// ffiResult runs fn through recoverToError, so failures are also recorded
+// as the last error and panics are recovered, and packs the value or the
+// error into an FfiResult.
+func ffiResult(fn func() (C.longlong, error)) C.FfiResult {
+	var value C.longlong
+	var err error
+	if code := recoverToError(func() error {
+		value, err = fn()
+		return err
+	}); code == ErrPanic {
+		err = newError(ErrPanic, "%s", loadLastError())
+	}
+	r := resultFromError(err)
+	if err == nil {
+		r.value = value
+	}
+	return r
+}
+
+// DivideSafe returns a / b truncated toward zero in an FfiResult. A zero b
+// yields ErrInvalidArg with a message instead of a value. The message is also
+// recorded as the last error, as for any other export.
+//
+//export DivideSafe
+func DivideSafe(a, b C.longlong) C.FfiResult {
+	defer trackCall("DivideSafe", time.Now())
+	return ffiResult(func() (C.longlong, error) {
+		if b == 0 {
+			return 0, newError(ErrInvalidArg, "DivideSafe: division by zero")
+		}
+		return a / b, nil
+	})
+}
+
+// ParseInt parses s as a base-10 int64, with an optional sign and no
+// surrounding spaces, and returns it in an FfiResult. Malformed or
+// out-of-range input yields ErrInvalidArg with the strconv error message; a
+// nil s yields ErrNullPointer.
+//
+//export ParseInt
+func ParseInt(s *C.char) C.FfiResult {
+	defer trackCall("ParseInt", time.Now())
+	return ffiResult(func() (C.longlong, error) {
+		if s == nil {
+			return 0, newError(ErrNullPointer, "ParseInt: nil string")
+		}
+		n, err := strconv.ParseInt(C.GoString(s), 10, 64)
+		return C.longlong(n), err
+	})
+}
+
+// FreeResult releases the message embedded in an FfiResult. A result without
+// a message is a no-op; each message must be freed exactly once.
+//
+//export FreeResult
+func FreeResult(r C.FfiResult) {
+	defer trackCall("FreeResult", time.Now())
+	if r.message == nil {
+		return
+	}
+	trackFree()
+	C.free(unsafe.Pointer(r.message))
+}
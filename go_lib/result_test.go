@@ -0,0 +1,101 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"unsafe"
+)
+
+func TestFfiResultLayout(t *testing.T) {
+	r := DivideSafe(1, 1)
+	if got := unsafe.Sizeof(r); got != 24 {
+		t.Errorf("sizeof(FfiResult) = %d, want 24", got)
+	}
+	if got := unsafe.Offsetof(r.message); got != 8 {
+		t.Errorf("offsetof(FfiResult.message) = %d, want 8", got)
+	}
+	if got := unsafe.Offsetof(r.value); got != 16 {
+		t.Errorf("offsetof(FfiResult.value) = %d, want 16", got)
+	}
+}
+
+func TestDivideSafe(t *testing.T) {
+	tests := []struct {
+		a, b, want int64
+	}{
+		{7, 2, 3},
+		{-7, 2, -3},
+		{0, 5, 0},
+		{1 << 62, -1, -(1 << 62)},
+	}
+	for _, tt := range tests {
+		r := DivideSafe(cLongLong(tt.a), cLongLong(tt.b))
+		if r.code != ErrOK || r.message != nil || int64(r.value) != tt.want {
+			t.Errorf("DivideSafe(%d, %d) = {code %d, message %p, value %d}; want {0, nil, %d}",
+				tt.a, tt.b, r.code, r.message, r.value, tt.want)
+		}
+		FreeResult(r)
+	}
+}
+
+func TestDivideSafeByZero(t *testing.T) {
+	lockThread(t)
+
+	r := DivideSafe(1, 0)
+	defer FreeResult(r)
+
+	if r.code != ErrInvalidArg {
+		t.Errorf("code = %d, want %d", r.code, ErrInvalidArg)
+	}
+	if r.message == nil {
+		t.Fatal("failed result has no message")
+	}
+	if got, want := goString(r.message), "DivideSafe: division by zero"; got != want {
+		t.Errorf("message = %q, want %q", got, want)
+	}
+	if got := lastErrorString(); got != goString(r.message) {
+		t.Errorf("last error = %q, want the result message", got)
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	lockThread(t)
+
+	tests := []struct {
+		in      string
+		code    int
+		value   int64
+		message string
+	}{
+		{"123", ErrOK, 123, ""},
+		{"-9223372036854775808", ErrOK, math.MinInt64, ""},
+		{"abc", ErrInvalidArg, 0, `strconv.ParseInt: parsing "abc": invalid syntax`},
+		{"", ErrInvalidArg, 0, `strconv.ParseInt: parsing "": invalid syntax`},
+		{"9223372036854775808", ErrInvalidArg, 0, `strconv.ParseInt: parsing "9223372036854775808": value out of range`},
+	}
+	for _, tt := range tests {
+		s := cString(tt.in)
+		r := ParseInt(s)
+		FreeCString(s)
+
+		if int(r.code) != tt.code {
+			t.Errorf("ParseInt(%q) code = %d, want %d", tt.in, r.code, tt.code)
+		}
+		if tt.code == ErrOK {
+			if r.message != nil || int64(r.value) != tt.value {
+				t.Errorf("ParseInt(%q) = {message %p, value %d}, want {nil, %d}", tt.in, r.message, r.value, tt.value)
+			}
+		} else if r.message == nil {
+			t.Errorf("ParseInt(%q) failed without a message", tt.in)
+		} else if got := goString(r.message); got != tt.message {
+			t.Errorf("ParseInt(%q) message = %q, want %q", tt.in, got, tt.message)
+		}
+		FreeResult(r)
+	}
+
+	r := ParseInt(nil)
+	defer FreeResult(r)
+	if r.code != ErrNullPointer || r.message == nil {
+		t.Errorf("ParseInt(nil) = {code %d, message %p}, want ErrNullPointer with a message", r.code, r.message)
+	}
+}
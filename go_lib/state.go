@@ -0,0 +1,187 @@
+package main
+
+import "C"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stateVersion is bumped whenever the ExportState format changes.
+const stateVersion = 2
+
+// rngState holds the generator's PCG state as written by MarshalBinary,
+// base64-encoded in the JSON.
+type rngState struct {
+	State []byte `json:"state"`
+}
+
+type librarySnapshot struct {
+	Version      int               `json:"version"`
+	Accumulators map[string]int64  `json:"accumulators"`
+	Config       map[string]string `json:"config"`
+	RNG          rngState          `json:"rng"`
+}
+
+// ExportState returns the library's restorable state as JSON:
+//
+//	{"version":2,"accumulators":{"1":42},"config":{"k":"v"},"rng":{"state":"cGNnOrCntnsJYNOBQL26ZnRjP9E="}}
+//
+// Accumulators are keyed by handle so the host's handles stay valid after
+// ImportState. Running computations and registered callbacks are not part of
+// the state. The result must be released with FreeCString.
+//
+//export ExportState
+func ExportState() (out *C.char) {
+	defer trackCall("ExportState", time.Now())
+	recoverToError(func() error {
+		snap := librarySnapshot{
+			Version:      stateVersion,
+			Accumulators: map[string]int64{},
+			Config:       map[string]string{},
+		}
+		accumulators.Range(func(key, value any) bool {
+			snap.Accumulators[strconv.FormatUint(uint64(key.(uintptr)), 10)] = value.(*accumulator).total.Load()
+			return true
+		})
+		configMu.RLock()
+		for k, v := range config {
+			snap.Config[k] = v
+		}
+		configMu.RUnlock()
+		rngMu.Lock()
+		state, err := rngSource.MarshalBinary()
+		rngMu.Unlock()
+		if err != nil {
+			return err
+		}
+		snap.RNG = rngState{State: state}
+
+		encoded, err := json.Marshal(snap)
+		if err != nil {
+			return err
+		}
+		out = allocString(string(encoded))
+		return nil
+	})
+	return out
+}
+
+// ImportState restores state produced by ExportState, typically right after
+// the library was reloaded. Accumulators are recreated under their exported
+// handles, replacing any with the same handle; config keys are set; the
+// random generator resumes where it left off. Restoring is best-effort: an
+// unknown section, a missing section, a version mismatch, a bad accumulator
+// handle or a malformed rng state is skipped, the rest is restored, and the
+// call returns ErrPartialState with the last error listing what was
+// skipped. Input that is not a JSON object returns ErrInvalidArg and
+// restores nothing; a nil s returns ErrNullPointer.
+//
+//export ImportState
+func ImportState(s *C.char) C.int {
+	defer trackCall("ImportState", time.Now())
+	return recoverToError(func() error {
+		if s == nil {
+			return newError(ErrNullPointer, "ImportState: nil string")
+		}
+		var sections map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(C.GoString(s)), &sections); err != nil {
+			return newError(ErrInvalidArg, "ImportState: %v", err)
+		}
+
+		var skipped []string
+		skip := func(format string, args ...any) {
+			skipped = append(skipped, fmt.Sprintf(format, args...))
+		}
+		section := func(name string, v any) bool {
+			raw, ok := sections[name]
+			delete(sections, name)
+			if !ok {
+				skip("missing %s", name)
+				return false
+			}
+			if err := json.Unmarshal(raw, v); err != nil {
+				skip("%s: %v", name, err)
+				return false
+			}
+			return true
+		}
+
+		var version int
+		if section("version", &version) && version != stateVersion {
+			skip("version %d, want %d", version, stateVersion)
+		}
+
+		var accs map[string]int64
+		if section("accumulators", &accs) {
+			restoreAccumulators(accs, skip)
+		}
+
+		var cfg map[string]string
+		if section("config", &cfg) {
+			configMu.Lock()
+			for k, v := range cfg {
+				config[k] = v
+			}
+			configMu.Unlock()
+		}
+
+		var r rngState
+		if section("rng", &r) {
+			rngMu.Lock()
+			err := rngSource.UnmarshalBinary(r.State)
+			rngMu.Unlock()
+			if err != nil {
+				skip("rng: %v", err)
+			}
+		}
+
+		unknown := make([]string, 0, len(sections))
+		for name := range sections {
+			unknown = append(unknown, name)
+		}
+		sort.Strings(unknown)
+		for _, name := range unknown {
+			skip("unknown section %s", name)
+		}
+
+		if len(skipped) > 0 {
+			return newError(ErrPartialState, "ImportState: skipped %s", strings.Join(skipped, "; "))
+		}
+		return nil
+	})
+}
+
+// restoreAccumulators stores each total under its handle and moves the
+// handle counter past the largest one so NewAccumulator never reuses them.
+func restoreAccumulators(totals map[string]int64, skip func(string, ...any)) {
+	keys := make([]string, 0, len(totals))
+	for key := range totals {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		total := totals[key]
+		id, err := strconv.ParseUint(key, 10, 64)
+		if err == nil && id == 0 {
+			err = errors.New("handle 0 is never issued")
+		}
+		if err != nil {
+			skip("accumulator %q: %v", key, err)
+			continue
+		}
+		acc := &accumulator{}
+		acc.total.Store(total)
+		accumulators.Store(uintptr(id), acc)
+		for {
+			next := nextAccumulatorID.Load()
+			if next >= uintptr(id) || nextAccumulatorID.CompareAndSwap(next, uintptr(id)) {
+				break
+			}
+		}
+	}
+}
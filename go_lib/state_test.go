@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func exportState(t *testing.T) string {
+	t.Helper()
+	out := ExportState()
+	if out == nil {
+		t.Fatal("ExportState returned nil")
+	}
+	defer FreeCString(out)
+	return goString(out)
+}
+
+func importState(s string) int {
+	in := cString(s)
+	defer FreeCString(in)
+	return int(ImportState(in))
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	lockThread(t)
+
+	a, b := NewAccumulator(), NewAccumulator()
+	AccumulatorAdd(a, 40)
+	AccumulatorAdd(b, -7)
+	setConfig(t, "state.mode", "fast")
+	SeedRandom(99)
+	NextRandom()
+	NextRandom()
+	wantNext := NextRandom()
+	SeedRandom(99)
+	NextRandom()
+	NextRandom()
+
+	snapshot := exportState(t)
+	var decoded librarySnapshot
+	if err := json.Unmarshal([]byte(snapshot), &decoded); err != nil {
+		t.Fatalf("ExportState returned invalid JSON %q: %v", snapshot, err)
+	}
+
+	LibShutdown()
+
+	if code := importState(snapshot); code != ErrOK {
+		t.Fatalf("ImportState = %d (%s), want %d", code, lastErrorString(), ErrOK)
+	}
+	if got := AccumulatorAdd(a, 2); got != 42 {
+		t.Errorf("restored accumulator a = %d, want 42", got)
+	}
+	if got := AccumulatorAdd(b, 0); got != -7 {
+		t.Errorf("restored accumulator b = %d, want -7", got)
+	}
+	if got, ok := getConfig("state.mode"); !ok || got != "fast" {
+		t.Errorf("restored config state.mode = %q, %v; want fast", got, ok)
+	}
+	if got := NextRandom(); got != wantNext {
+		t.Errorf("NextRandom after restore = %d, want %d", got, wantNext)
+	}
+	if c := NewAccumulator(); c == a || c == b {
+		t.Errorf("NewAccumulator reused restored handle %d", c)
+	}
+
+	LibShutdown()
+}
+
+func TestImportStatePartial(t *testing.T) {
+	lockThread(t)
+	defer LibShutdown()
+
+	code := importState(`{"version":2,"accumulators":{"5":3,"0":1,"x":2},"extra":true}`)
+	if code != ErrPartialState {
+		t.Fatalf("ImportState = %d, want %d", code, ErrPartialState)
+	}
+	msg := lastErrorString()
+	for _, want := range []string{`accumulator "0"`, `accumulator "x"`, "missing config", "missing rng", "unknown section extra"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("last error %q does not mention %q", msg, want)
+		}
+	}
+	if got := AccumulatorAdd(5, 1); got != 4 {
+		t.Errorf("accumulator 5 = %d, want 4 despite the skipped parts", got)
+	}
+}
+
+func TestImportStateInvalid(t *testing.T) {
+	lockThread(t)
+
+	for _, s := range []string{"", "[]", "{"} {
+		if code := importState(s); code != ErrInvalidArg {
+			t.Errorf("ImportState(%q) = %d, want %d", s, code, ErrInvalidArg)
+		}
+	}
+	if code := ImportState(nil); code != ErrNullPointer {
+		t.Errorf("ImportState(nil) = %d, want %d", code, ErrNullPointer)
+	}
+}
+
+func TestStateRoundTripLongRun(t *testing.T) {
+	lockThread(t)
+	defer LibShutdown()
+
+	// Far more draws than replaying them one by one could restore quickly.
+	SeedRandom(3)
+	rngMu.Lock()
+	for i := 0; i < 1<<25; i++ {
+		rng.Int64()
+	}
+	rngMu.Unlock()
+	snapshot := exportState(t)
+	want := randomSequence(4)
+
+	LibShutdown()
+	if code := importState(snapshot); code != ErrOK {
+		t.Fatalf("ImportState = %d (%s), want %d", code, lastErrorString(), ErrOK)
+	}
+	got := randomSequence(4)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("NextRandom %d after restore = %d, want %d", i, got[i], want[i])
+		}
+	}
+}
+
+func TestImportStateBadRNG(t *testing.T) {
+	lockThread(t)
+	defer LibShutdown()
+
+	SeedRandom(3)
+	want := NextRandom()
+	SeedRandom(3)
+
+	code := importState(`{"version":2,"accumulators":{},"config":{},"rng":{"state":"AAAA"}}`)
+	if code != ErrPartialState {
+		t.Fatalf("ImportState = %d, want %d", code, ErrPartialState)
+	}
+	if msg := lastErrorString(); !strings.Contains(msg, "rng:") {
+		t.Errorf("last error %q does not mention the rng section", msg)
+	}
+	if got := NextRandom(); got != want {
+		t.Errorf("NextRandom after skipped rng = %d, want %d from the untouched generator", got, want)
+	}
+}
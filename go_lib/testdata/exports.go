@@ -0,0 +1,37 @@
+package main
+
+/*
+#include <stdint.h>
+
+typedef struct {
+	int x;
+} Sample;
+*/
+import "C"
+import "unsafe"
+
+//export Nothing
+func Nothing() {}
+
+//export Scalars
+func Scalars(a C.longlong, b C.double, n C.size_t, h C.uintptr_t) C.int {
+	return 0
+}
+
+//export Pointers
+func Pointers(s *C.char, argv **C.char, in *C.uchar, raw unsafe.Pointer) *C.char {
+	return nil
+}
+
+//export Named
+func Named(s C.Sample) (out C.Sample) {
+	return s
+}
+
+//export LongSignature
+func LongSignature(first, second, third, fourth *C.longlong, fifth, sixth C.size_t) C.longlong {
+	return 0
+}
+
+// notExported has no //export directive and is skipped.
+func notExported(a C.int) {}
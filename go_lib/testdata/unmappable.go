@@ -0,0 +1,6 @@
+package main
+
+import "C"
+
+//export TakesGoString
+func TakesGoString(s string) {}
@@ -0,0 +1,68 @@
+package main
+
+import "C"
+import (
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// Utf8Len returns the number of Unicode code points in s, not its byte
+// length. Invalid UTF-8 returns -1 and records ErrInvalidArg; a nil s
+// returns -1 and records ErrNullPointer.
+//
+//export Utf8Len
+func Utf8Len(s *C.char) (n C.longlong) {
+	defer trackCall("Utf8Len", time.Now())
+	n = -1
+	recoverToError(func() error {
+		if s == nil {
+			return newError(ErrNullPointer, "Utf8Len: nil string")
+		}
+		str := C.GoString(s)
+		if !utf8.ValidString(str) {
+			return newError(ErrInvalidArg, "Utf8Len: invalid UTF-8")
+		}
+		n = C.longlong(utf8.RuneCountInString(str))
+		return nil
+	})
+	return n
+}
+
+// IsValidUtf8 returns 1 if s is valid UTF-8 and 0 otherwise, including for
+// a nil s.
+//
+//export IsValidUtf8
+func IsValidUtf8(s *C.char) (valid C.int) {
+	defer trackCall("IsValidUtf8", time.Now())
+	recoverToError(func() error {
+		valid = cBool(s != nil && utf8.ValidString(C.GoString(s)))
+		return nil
+	})
+	return valid
+}
+
+// Utf16ToUtf8 decodes the len UTF-16 code units at ptr, joining surrogate
+// pairs, and returns the text as a new UTF-8 C string to be released with
+// FreeCString. Unpaired surrogates decode to U+FFFD, and a NUL code unit
+// ends the C string early. The input is borrowed;
+// a zero len returns "", and a nil ptr with a nonzero len returns nil and
+// records ErrNullPointer.
+//
+//export Utf16ToUtf8
+func Utf16ToUtf8(ptr *C.ushort, len C.size_t) (out *C.char) {
+	defer trackCall("Utf16ToUtf8", time.Now())
+	recoverToError(func() error {
+		if ptr == nil && len > 0 {
+			return newError(ErrNullPointer, "Utf16ToUtf8: nil pointer with length %d", len)
+		}
+		var units []uint16
+		if len > 0 {
+			units = unsafe.Slice((*uint16)(unsafe.Pointer(ptr)), len)
+		}
+		out = allocString(string(utf16.Decode(units)))
+		return nil
+	})
+	return out
+}
@@ -0,0 +1,97 @@
+package main
+
+import "testing"
+
+func TestUtf8Len(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		want  int64
+		valid int
+	}{
+		{"ascii", "hello", 5, 1},
+		{"empty", "", 0, 1},
+		{"two-byte", "é", 1, 1},
+		{"three-byte", "漢", 1, 1},
+		{"four-byte", "🚀", 1, 1},
+		{"mixed", "héllo漢🚀", 7, 1},
+		{"malformed", "ab\xff\xfe", -1, 0},
+		{"truncated", "\xe6\xbc", -1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := cString(tt.s)
+			defer FreeCString(s)
+
+			if got := Utf8Len(s); int64(got) != tt.want {
+				t.Errorf("Utf8Len(%q) = %d, want %d", tt.s, got, tt.want)
+			}
+			if got := IsValidUtf8(s); int(got) != tt.valid {
+				t.Errorf("IsValidUtf8(%q) = %d, want %d", tt.s, got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestUtf8LenErrors(t *testing.T) {
+	lockThread(t)
+
+	s := cString("\xc3")
+	defer FreeCString(s)
+	if got := Utf8Len(s); got != -1 {
+		t.Errorf("Utf8Len(invalid) = %d, want -1", got)
+	}
+	if msg := lastErrorString(); msg == "" {
+		t.Error("invalid UTF-8 should set the last error")
+	}
+
+	if got := Utf8Len(nil); got != -1 {
+		t.Errorf("Utf8Len(nil) = %d, want -1", got)
+	}
+	if got := IsValidUtf8(nil); got != 0 {
+		t.Errorf("IsValidUtf8(nil) = %d, want 0", got)
+	}
+}
+
+func TestUtf16ToUtf8(t *testing.T) {
+	tests := []struct {
+		name  string
+		units []uint16
+		want  string
+	}{
+		{"empty", nil, ""},
+		{"ascii", []uint16{'G', 'o'}, "Go"},
+		{"bmp", []uint16{0x00E9, 0x6F22, 0x5B57}, "é漢字"},
+		{"surrogate pair", []uint16{0xD83D, 0xDE80}, "🚀"},
+		{"lone high surrogate", []uint16{'a', 0xD83D, 'b'}, "a�b"},
+		{"lone low surrogate", []uint16{0xDE80}, "�"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ptr := cUShorts(tt.units)
+			defer cFree(ptr)
+
+			out := Utf16ToUtf8(ptr, cSizeT(len(tt.units)))
+			if out == nil {
+				t.Fatal("Utf16ToUtf8 returned nil")
+			}
+			defer FreeCString(out)
+			if got := goString(out); got != tt.want {
+				t.Errorf("Utf16ToUtf8(%x) = %q (% x), want %q", tt.units, got, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUtf16ToUtf8Nil(t *testing.T) {
+	lockThread(t)
+
+	if out := Utf16ToUtf8(nil, 2); out != nil {
+		FreeCString(out)
+		t.Fatal("Utf16ToUtf8(nil, 2) should return nil")
+	}
+	if msg := lastErrorString(); msg == "" {
+		t.Error("nil input should set the last error")
+	}
+}
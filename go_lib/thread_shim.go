@@ -0,0 +1,24 @@
+package main
+
+/*
+#ifdef _WIN32
+#include <windows.h>
+static inline unsigned long long current_thread_id(void) {
+	return (unsigned long long)GetCurrentThreadId();
+}
+#else
+#include <pthread.h>
+static inline unsigned long long current_thread_id(void) {
+	return (unsigned long long)pthread_self();
+}
+#endif
+*/
+import "C"
+
+// currentThreadID identifies the OS thread the caller is running on. A call
+// coming in from the host stays on the host's thread for its whole duration,
+// so this is stable across one export call and between successive calls
+// from the same host thread.
+func currentThreadID() uint64 {
+	return uint64(C.current_thread_id())
+}
@@ -0,0 +1,50 @@
+package main
+
+import "C"
+import "time"
+
+// FormatUnixNanos formats ns nanoseconds since the Unix epoch as an RFC 3339
+// timestamp in UTC, with as many fractional digits as needed and none for a
+// whole second, e.g. "2024-03-01T12:00:00.5Z". Negative values are before
+// 1970. The result must be released with FreeCString.
+//
+//export FormatUnixNanos
+func FormatUnixNanos(ns C.longlong) (out *C.char) {
+	defer trackCall("FormatUnixNanos", time.Now())
+	recoverToError(func() error {
+		out = allocString(time.Unix(0, int64(ns)).UTC().Format(time.RFC3339Nano))
+		return nil
+	})
+	return out
+}
+
+// ParseRFC3339 parses an RFC 3339 timestamp, with an optional fractional
+// second, and returns it as nanoseconds since the Unix epoch. On failure it
+// returns -1 and records the error; since -1 is also the valid result for
+// 1969-12-31T23:59:59.999999999Z, check GetLastError to tell them apart. A
+// nil s records ErrNullPointer, and a time outside the int64 nanosecond
+// range (years 1678 to 2262) records ErrInvalidArg.
+//
+//export ParseRFC3339
+func ParseRFC3339(s *C.char) (ns C.longlong) {
+	defer trackCall("ParseRFC3339", time.Now())
+	code := recoverToError(func() error {
+		if s == nil {
+			return newError(ErrNullPointer, "ParseRFC3339: nil string")
+		}
+		t, err := time.Parse(time.RFC3339Nano, C.GoString(s))
+		if err != nil {
+			return newError(ErrInvalidArg, "ParseRFC3339: %v", err)
+		}
+		n := t.UnixNano()
+		if !time.Unix(0, n).Equal(t) {
+			return newError(ErrInvalidArg, "ParseRFC3339: %s is outside the Unix nanosecond range", C.GoString(s))
+		}
+		ns = C.longlong(n)
+		return nil
+	})
+	if code != ErrOK {
+		return -1
+	}
+	return ns
+}
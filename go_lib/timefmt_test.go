@@ -0,0 +1,65 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTimeRoundTrip(t *testing.T) {
+	tests := []struct {
+		ns   int64
+		text string
+	}{
+		{0, "1970-01-01T00:00:00Z"},
+		{1_709_294_400_000_000_000, "2024-03-01T12:00:00Z"},
+		{1_709_294_400_123_456_789, "2024-03-01T12:00:00.123456789Z"},
+		{1_709_294_400_500_000_000, "2024-03-01T12:00:00.5Z"},
+		{-1, "1969-12-31T23:59:59.999999999Z"},
+		{-86_400_000_000_000, "1969-12-31T00:00:00Z"},
+		{-1_000_000_000_000_000_001, "1938-04-24T22:13:19.999999999Z"},
+	}
+
+	for _, tt := range tests {
+		out := FormatUnixNanos(cLongLong(tt.ns))
+		if got := goString(out); got != tt.text {
+			t.Errorf("FormatUnixNanos(%d) = %q, want %q", tt.ns, got, tt.text)
+		}
+		FreeCString(out)
+
+		s := cString(tt.text)
+		if got := ParseRFC3339(s); int64(got) != tt.ns {
+			t.Errorf("ParseRFC3339(%q) = %d, want %d", tt.text, got, tt.ns)
+		}
+		FreeCString(s)
+	}
+}
+
+func TestParseRFC3339Offset(t *testing.T) {
+	s := cString("2024-03-01T14:00:00+02:00")
+	defer FreeCString(s)
+	if got := ParseRFC3339(s); got != 1_709_294_400_000_000_000 {
+		t.Errorf("ParseRFC3339 with offset = %d, want 1709294400000000000", got)
+	}
+}
+
+func TestParseRFC3339Errors(t *testing.T) {
+	lockThread(t)
+
+	for _, text := range []string{"", "yesterday", "2024-03-01 12:00:00", "3000-01-01T00:00:00Z"} {
+		s := cString(text)
+		if got := ParseRFC3339(s); got != -1 {
+			t.Errorf("ParseRFC3339(%q) = %d, want -1", text, got)
+		}
+		if msg := lastErrorString(); !strings.HasPrefix(msg, "ParseRFC3339:") {
+			t.Errorf("ParseRFC3339(%q) last error = %q", text, msg)
+		}
+		FreeCString(s)
+	}
+
+	if got := ParseRFC3339(nil); got != -1 {
+		t.Errorf("ParseRFC3339(nil) = %d, want -1", got)
+	}
+	if msg := lastErrorString(); msg == "" {
+		t.Error("nil input should set the last error")
+	}
+}
@@ -7,3 +7,70 @@ mod bindings {
 }
 
 pub use bindings::*;
+
+/// `extern "C"` declarations generated from the Go `//export` functions by
+/// `go_lib/gen_bindings.go`. Run `go generate` in `go_lib` after changing an
+/// export; a Go test fails if this file is stale.
+#[allow(non_snake_case)]
+pub mod exports;
+
+/// Converts the `0`/`1` returned by boolean exports into a Rust `bool`.
+///
+/// All boolean exports return exactly `0` or `1`; any nonzero value is
+/// treated as `true` to stay robust.
+pub fn c_bool(value: std::os::raw::c_int) -> bool {
+    value != 0
+}
+
+/// Splits an `Option<i64>` into the `(value, has_value)` pair that optional
+/// integer exports such as `MaybeDouble` take. `None` becomes `(0, 0)`.
+pub fn option_to_c(value: Option<i64>) -> (i64, std::os::raw::c_int) {
+    match value {
+        Some(v) => (v, 1),
+        None => (0, 0),
+    }
+}
+
+/// Rebuilds an `Option<i64>` from a value and the `has_value` flag an
+/// optional integer export wrote through its out-pointer.
+pub fn option_from_c(value: i64, has_value: std::os::raw::c_int) -> Option<i64> {
+    c_bool(has_value).then_some(value)
+}
+
+impl FfiResult {
+    /// Converts the result into a `Result`, copying the Go error message
+    /// into the `Err` side before releasing it. A code the library does not
+    /// define maps to `FfiError::InvalidArg`; a missing message becomes an
+    /// empty string.
+    ///
+    /// # Safety
+    ///
+    /// `self` must come straight from an export returning `FfiResult`, and
+    /// neither it nor a copy of it may have been passed to `FreeResult`.
+    pub unsafe fn into_result(self) -> Result<i64, (crate::FfiError, String)> {
+        let code = self.code;
+        let value = self.value;
+        let message = if self.message.is_null() {
+            String::new()
+        } else {
+            std::ffi::CStr::from_ptr(self.message)
+                .to_string_lossy()
+                .into_owned()
+        };
+        FreeResult(self);
+        match crate::FfiError::from_code(code) {
+            Some(crate::FfiError::Ok) => Ok(value),
+            Some(err) => Err((err, message)),
+            None => Err((crate::FfiError::InvalidArg, message)),
+        }
+    }
+}
+
+/// Converts the `-1`/`0`/`1` returned by comparison exports such as
+/// `CompareInt` into an [`std::cmp::Ordering`].
+///
+/// The exports never return anything else; any other negative or positive
+/// value is treated as `Less` or `Greater` to stay robust.
+pub fn c_ordering(value: std::os::raw::c_int) -> std::cmp::Ordering {
+    value.cmp(&0)
+}
@@ -0,0 +1,42 @@
+// Code generated by go_lib/gen_errors.go; DO NOT EDIT.
+
+/// Error codes returned by the Go library.
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Hash)]
+#[repr(i32)]
+pub enum FfiError {
+    /// The call succeeded.
+    Ok = 0,
+    /// Go panicked and the panic was recovered.
+    Panic = 1,
+    /// A required pointer argument was nil.
+    NullPointer = 2,
+    /// An argument was outside its accepted range.
+    InvalidArg = 3,
+    /// The operation was cancelled before it finished.
+    Cancelled = 4,
+    /// The library is older than the caller requires.
+    VersionMismatch = 5,
+    /// State was restored only in part.
+    PartialState = 6,
+}
+
+impl FfiError {
+    /// Converts a raw code returned by the library into an `FfiError`.
+    pub fn from_code(code: i32) -> Option<Self> {
+        match code {
+            0 => Some(FfiError::Ok),
+            1 => Some(FfiError::Panic),
+            2 => Some(FfiError::NullPointer),
+            3 => Some(FfiError::InvalidArg),
+            4 => Some(FfiError::Cancelled),
+            5 => Some(FfiError::VersionMismatch),
+            6 => Some(FfiError::PartialState),
+            _ => None,
+        }
+    }
+
+    /// Returns the raw code for this error.
+    pub fn code(self) -> i32 {
+        self as i32
+    }
+}
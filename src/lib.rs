@@ -1,4 +1,6 @@
 pub mod ffi;
+pub mod ffi_error;
+pub mod safe;
 #[cfg(feature = "auto-install")]
 mod installer;
 
@@ -6,6 +8,8 @@ use log::{debug, info};
 use semver::Version;
 use std::path::{Path, PathBuf};
 use std::sync::Once;
+
+pub use ffi_error::FfiError;
 static INIT: Once = Once::new();
 static mut DLL_HANDLE: Option<winapi::shared::minwindef::HMODULE> = None;
 
@@ -364,12 +368,10 @@ where
 
 #[allow(non_snake_case)]
 unsafe fn get_dll_version() -> Result<Version, DllError> {
-    let version_num = ffi::GetDLLVersion();
-    let major = (version_num / 10000) as u64;
-    let minor = ((version_num % 10000) / 100) as u64;
-    let patch = (version_num % 100) as u64;
+    let (mut major, mut minor, mut patch) = (0i64, 0i64, 0i64);
+    ffi::GetVersionComponents(&mut major, &mut minor, &mut patch);
 
-    Ok(Version::new(major, minor, patch))
+    Ok(Version::new(major as u64, minor as u64, patch as u64))
 }
 
 // Safe wrapper for version checking
@@ -0,0 +1,183 @@
+//! Safe wrappers around the raw exports in [`crate::ffi`].
+//!
+//! The `unsafe` blocks live here so application code does not need any.
+//! Strings returned by Go are held in a [`GoString`], which releases them
+//! with `FreeCString` when dropped.
+
+use crate::ffi;
+use std::ffi::{CStr, CString};
+use std::fmt;
+use std::os::raw::c_char;
+use std::ptr::NonNull;
+
+/// A C string allocated by the Go library, freed with `FreeCString` on drop.
+pub struct GoString {
+    ptr: NonNull<c_char>,
+}
+
+impl GoString {
+    /// Takes ownership of a string returned by an export, or returns `None`
+    /// for a null pointer.
+    ///
+    /// # Safety
+    ///
+    /// `ptr` must be null or a string returned by the Go library that has
+    /// not been freed and is not owned by anything else.
+    pub unsafe fn from_raw(ptr: *mut c_char) -> Option<Self> {
+        NonNull::new(ptr).map(|ptr| GoString { ptr })
+    }
+
+    /// Gives the pointer back without freeing it; release it with
+    /// `FreeCString` or [`GoString::from_raw`].
+    pub fn into_raw(self) -> *mut c_char {
+        let ptr = self.ptr.as_ptr();
+        std::mem::forget(self);
+        ptr
+    }
+
+    pub fn as_c_str(&self) -> &CStr {
+        // SAFETY: the pointer came from Go as a NUL-terminated string and
+        // stays valid until drop.
+        unsafe { CStr::from_ptr(self.ptr.as_ptr()) }
+    }
+
+    /// Copies the string out, replacing invalid UTF-8 with U+FFFD.
+    pub fn to_string_lossy(&self) -> String {
+        self.as_c_str().to_string_lossy().into_owned()
+    }
+}
+
+impl Drop for GoString {
+    fn drop(&mut self) {
+        // SAFETY: GoString owns the pointer and frees it exactly once.
+        unsafe { ffi::FreeCString(self.ptr.as_ptr()) }
+    }
+}
+
+impl fmt::Debug for GoString {
+    fn fmt(&self, f: &mut fmt::Formatter<'_>) -> fmt::Result {
+        fmt::Debug::fmt(self.as_c_str(), f)
+    }
+}
+
+impl fmt::Display for GoString {
+    fn fmt(&self, f: &mut fmt::Formatter<'_>) -> fmt::Result {
+        f.write_str(&self.as_c_str().to_string_lossy())
+    }
+}
+
+// SAFETY: the string is immutable and FreeCString may be called from any
+// thread.
+unsafe impl Send for GoString {}
+unsafe impl Sync for GoString {}
+
+fn c_string(s: &str) -> CString {
+    CString::new(s).expect("string passed to Go contains a NUL byte")
+}
+
+/// Returns the message recorded by the last failing call on this thread.
+pub fn last_error() -> Option<String> {
+    // SAFETY: GetLastError returns null or a fresh string for us to free.
+    unsafe { GoString::from_raw(ffi::GetLastError()) }.map(|s| s.to_string_lossy())
+}
+
+/// Returns `a + b`, wrapping on overflow.
+pub fn add_numbers(a: i64, b: i64) -> i64 {
+    // SAFETY: plain scalar call.
+    unsafe { ffi::AddNumbers(a, b) }
+}
+
+/// Returns `a` followed by `b`.
+///
+/// # Panics
+///
+/// Panics if `a` or `b` contains a NUL byte, which cannot cross the C
+/// boundary.
+pub fn concat_strings(a: &str, b: &str) -> String {
+    let (a, b) = (c_string(a), c_string(b));
+    // SAFETY: Go only borrows the inputs for the call and returns a fresh
+    // string for us to free.
+    let joined = unsafe {
+        GoString::from_raw(ffi::ConcatStrings(
+            a.as_ptr() as *mut _,
+            b.as_ptr() as *mut _,
+        ))
+    };
+    joined
+        .expect("ConcatStrings returned null")
+        .to_string_lossy()
+}
+
+/// Returns the library version as a semver string such as "0.1.0".
+pub fn version_string() -> String {
+    // SAFETY: GetDLLVersionString returns a fresh string for us to free.
+    unsafe { GoString::from_raw(ffi::GetDLLVersionString()) }
+        .expect("GetDLLVersionString returned null")
+        .to_string_lossy()
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use std::sync::Mutex;
+
+    // The leak test reads the library's global call metrics.
+    static LOCK: Mutex<()> = Mutex::new(());
+
+    fn calls(metrics: &str, export: &str) -> u64 {
+        let key = format!("\"{export}\":{{\"calls\":");
+        metrics.find(&key).map_or(0, |start| {
+            metrics[start + key.len()..]
+                .chars()
+                .take_while(|c| c.is_ascii_digit())
+                .collect::<String>()
+                .parse()
+                .unwrap()
+        })
+    }
+
+    fn metrics() -> String {
+        unsafe { GoString::from_raw(ffi::GetMetricsJSON()) }
+            .unwrap()
+            .to_string_lossy()
+    }
+
+    #[test]
+    fn test_safe_results() {
+        let _guard = LOCK.lock().unwrap();
+
+        assert_eq!(add_numbers(40, 2), 42);
+        assert_eq!(add_numbers(i64::MAX, 1), i64::MIN);
+        assert_eq!(concat_strings("héllo ", "漢字 🚀"), "héllo 漢字 🚀");
+        assert_eq!(concat_strings("", ""), "");
+        assert_eq!(version_string(), "0.1.0");
+    }
+
+    #[test]
+    fn test_go_string_frees_on_drop() {
+        let _guard = LOCK.lock().unwrap();
+
+        let before = metrics();
+        for i in 0..50 {
+            assert_eq!(concat_strings("n", &i.to_string()), format!("n{i}"));
+        }
+        let kept = unsafe { GoString::from_raw(ffi::GetDLLVersionString()) }.unwrap();
+        let raw = kept.into_raw();
+        let after = metrics();
+
+        // Every string Go returned has been freed except the one we took back
+        // with into_raw, and each metrics() call frees its own snapshot
+        // (`before` is freed before the loop, `after` only after the read).
+        let concats = calls(&after, "ConcatStrings") - calls(&before, "ConcatStrings");
+        let frees = calls(&after, "FreeCString") - calls(&before, "FreeCString");
+        assert_eq!(concats, 50);
+        assert_eq!(
+            frees,
+            concats + 1,
+            "One free per string plus the first snapshot"
+        );
+
+        drop(unsafe { GoString::from_raw(raw) });
+        assert!(unsafe { GoString::from_raw(std::ptr::null_mut()) }.is_none());
+    }
+}
@@ -48,3 +48,1008 @@ fn test_error_handling() {
         Err(e) => println!("Expected error occurred: {:?}", e),
     }
 }
+
+#[test]
+fn test_dll_version_string() {
+    use rust_go_ffi::ffi::{FreeCString, GetDLLVersionString};
+    use std::ffi::CStr;
+
+    unsafe {
+        let ptr = GetDLLVersionString();
+        assert!(!ptr.is_null(), "Version string should not be null");
+
+        let version = CStr::from_ptr(ptr).to_str().unwrap().to_owned();
+        FreeCString(ptr);
+
+        assert_eq!(version, "0.1.0");
+    }
+}
+
+#[test]
+fn test_concat_strings() {
+    use rust_go_ffi::ffi::{ConcatStrings, FreeCString};
+    use std::ffi::{CStr, CString};
+
+    let a = CString::new("héllo ").unwrap();
+    let b = CString::new("漢字 🚀").unwrap();
+
+    unsafe {
+        let ptr = ConcatStrings(a.as_ptr() as *mut _, b.as_ptr() as *mut _);
+        assert!(!ptr.is_null(), "Concatenation result should not be null");
+
+        let joined = CStr::from_ptr(ptr).to_str().unwrap().to_owned();
+        FreeCString(ptr);
+
+        assert_eq!(joined, "héllo 漢字 🚀");
+    }
+
+    // The inputs are still owned (and freed) by Rust.
+    assert_eq!(a.to_str().unwrap(), "héllo ");
+    assert_eq!(b.to_str().unwrap(), "漢字 🚀");
+}
+
+#[test]
+fn test_go_panic_is_recovered() {
+    use rust_go_ffi::ffi::{FreeCString, GetLastError, TriggerPanic};
+    use rust_go_ffi::FfiError;
+    use std::ffi::{CStr, CString};
+
+    let msg = CString::new("deliberate panic").unwrap();
+
+    unsafe {
+        let code = TriggerPanic(msg.as_ptr() as *mut _);
+        assert_eq!(
+            FfiError::from_code(code),
+            Some(FfiError::Panic),
+            "A Go panic should be reported as error code 1"
+        );
+
+        let err = GetLastError();
+        assert!(!err.is_null(), "Panic message should be recorded");
+        let text = CStr::from_ptr(err).to_str().unwrap().to_owned();
+        FreeCString(err);
+
+        assert!(
+            text.contains("deliberate panic"),
+            "Unexpected panic message: {}",
+            text
+        );
+    }
+}
+
+#[test]
+fn test_ffi_error_codes() {
+    use rust_go_ffi::FfiError;
+
+    for err in [
+        FfiError::Ok,
+        FfiError::Panic,
+        FfiError::NullPointer,
+        FfiError::InvalidArg,
+        FfiError::Cancelled,
+        FfiError::VersionMismatch,
+        FfiError::PartialState,
+    ] {
+        assert_eq!(FfiError::from_code(err.code()), Some(err));
+    }
+    assert_eq!(FfiError::Panic.code(), 1);
+    assert_eq!(FfiError::from_code(-1), None);
+}
+
+#[test]
+fn test_sum_array() {
+    use rust_go_ffi::ffi::SumArray;
+
+    let values: Vec<i64> = vec![1, 2, 3, 4, 5, -20];
+    let empty: Vec<i64> = Vec::new();
+
+    unsafe {
+        assert_eq!(SumArray(values.as_ptr() as *mut _, values.len()), -5);
+        assert_eq!(SumArray(empty.as_ptr() as *mut _, empty.len()), 0);
+        assert_eq!(SumArray(std::ptr::null_mut(), 0), 0);
+    }
+}
+
+#[test]
+fn test_float_arithmetic() {
+    use rust_go_ffi::ffi::{AddFloats, DivideFloats, IsNaN};
+
+    unsafe {
+        assert_eq!(AddFloats(1.5, 2.25), 3.75);
+        assert_eq!(DivideFloats(7.0, 2.0), 3.5);
+
+        let pos_inf = DivideFloats(1.0, 0.0);
+        assert!(pos_inf.is_infinite() && pos_inf.is_sign_positive());
+        assert_eq!(IsNaN(pos_inf), 0);
+
+        let neg_inf = DivideFloats(-1.0, 0.0);
+        assert!(neg_inf.is_infinite() && neg_inf.is_sign_negative());
+
+        let nan = DivideFloats(0.0, 0.0);
+        assert!(nan.is_nan());
+        assert_eq!(IsNaN(nan), 1);
+        assert_eq!(IsNaN(1.0), 0);
+    }
+}
+
+#[test]
+fn test_register_and_trigger_callback() {
+    use rust_go_ffi::ffi::{RegisterCallback, TriggerCallback};
+    use rust_go_ffi::FfiError;
+    use std::sync::atomic::{AtomicI64, Ordering};
+
+    static RECEIVED: AtomicI64 = AtomicI64::new(0);
+
+    extern "C" fn on_value(value: i64) {
+        RECEIVED.store(value, Ordering::SeqCst);
+    }
+
+    unsafe {
+        RegisterCallback(0);
+        assert_eq!(
+            FfiError::from_code(TriggerCallback(1)),
+            Some(FfiError::NullPointer),
+            "Triggering without a callback should fail"
+        );
+
+        RegisterCallback(on_value as usize);
+        assert_eq!(FfiError::from_code(TriggerCallback(42)), Some(FfiError::Ok));
+        RegisterCallback(0);
+    }
+
+    assert_eq!(RECEIVED.load(Ordering::SeqCst), 42);
+}
+
+#[test]
+fn test_is_even() {
+    use rust_go_ffi::ffi::{c_bool, IsEven};
+
+    unsafe {
+        for n in [0, 2, -2, -100, i64::MIN] {
+            assert_eq!(IsEven(n), 1, "{} should be even", n);
+            assert!(c_bool(IsEven(n)));
+        }
+        for n in [1, -1, 7, -7, i64::MAX] {
+            assert_eq!(IsEven(n), 0, "{} should be odd", n);
+            assert!(!c_bool(IsEven(n)));
+        }
+    }
+}
+
+#[test]
+fn test_fill_buffer() {
+    use rust_go_ffi::ffi::FillBuffer;
+
+    let mut buf = vec![0u8; 16];
+
+    let written = unsafe { FillBuffer(buf.as_mut_ptr(), buf.len()) };
+    assert_eq!(written, buf.len());
+    assert_eq!(buf, (0..16).collect::<Vec<u8>>());
+
+    assert_eq!(unsafe { FillBuffer(std::ptr::null_mut(), 0) }, 0);
+}
+
+#[test]
+fn test_process_point() {
+    use rust_go_ffi::ffi::{Point, ProcessPoint};
+    use std::mem::{align_of, size_of, MaybeUninit};
+    use std::ptr::addr_of;
+
+    #[repr(C)]
+    struct RustPoint {
+        x: i64,
+        y: i64,
+        weight: f64,
+    }
+
+    assert_eq!(size_of::<Point>(), size_of::<RustPoint>());
+    assert_eq!(align_of::<Point>(), align_of::<RustPoint>());
+    assert_eq!(size_of::<Point>(), 24, "Point should have no padding");
+
+    let go = MaybeUninit::<Point>::uninit();
+    let rust = MaybeUninit::<RustPoint>::uninit();
+    let go_base = go.as_ptr() as usize;
+    let rust_base = rust.as_ptr() as usize;
+    unsafe {
+        let go_ptr = go.as_ptr();
+        let rust_ptr = rust.as_ptr();
+        assert_eq!(
+            addr_of!((*go_ptr).x) as usize - go_base,
+            addr_of!((*rust_ptr).x) as usize - rust_base
+        );
+        assert_eq!(
+            addr_of!((*go_ptr).y) as usize - go_base,
+            addr_of!((*rust_ptr).y) as usize - rust_base
+        );
+        assert_eq!(
+            addr_of!((*go_ptr).weight) as usize - go_base,
+            addr_of!((*rust_ptr).weight) as usize - rust_base
+        );
+    }
+
+    let point = Point {
+        x: 2,
+        y: 3,
+        weight: 1.5,
+    };
+    assert_eq!(unsafe { ProcessPoint(point) }, 7.5);
+}
+
+#[test]
+fn test_make_point() {
+    use rust_go_ffi::ffi::MakePoint;
+
+    for weight in [0.0, -0.0, 0.1, 1.5, -2.75, f64::MAX, f64::MIN_POSITIVE] {
+        let point = unsafe { MakePoint(i64::MAX, i64::MIN, weight) };
+        assert_eq!(point.x, i64::MAX);
+        assert_eq!(point.y, i64::MIN);
+        assert_eq!(
+            point.weight.to_bits(),
+            weight.to_bits(),
+            "weight {} should round-trip exactly",
+            weight
+        );
+    }
+}
+
+#[test]
+fn test_generated_exports() {
+    use rust_go_ffi::ffi::exports;
+
+    unsafe {
+        assert_eq!(exports::AddNumbers(2, 3), 5);
+        assert_eq!(exports::GetDLLVersion(), 100);
+    }
+}
+
+#[test]
+fn test_multiply_and_mod_numbers() {
+    use rust_go_ffi::ffi::{FreeCString, GetLastError, ModNumbers, MultiplyNumbers};
+
+    unsafe {
+        assert_eq!(MultiplyNumbers(6, 7), 42);
+        assert_eq!(MultiplyNumbers(-6, 7), -42);
+        assert_eq!(MultiplyNumbers(i64::MAX, 2), i64::MAX.wrapping_mul(2));
+        assert_eq!(MultiplyNumbers(i64::MIN, -1), i64::MIN);
+
+        assert_eq!(ModNumbers(7, 3), 1);
+        assert_eq!(ModNumbers(-7, 3), -1);
+        assert_eq!(ModNumbers(i64::MIN, -1), 0);
+        assert!(GetLastError().is_null(), "Successful calls leave no error");
+
+        assert_eq!(ModNumbers(7, 0), 0);
+        let err = GetLastError();
+        assert!(!err.is_null(), "A zero divisor should set the last error");
+        FreeCString(err);
+    }
+}
+
+#[test]
+fn test_add_checked() {
+    use rust_go_ffi::ffi::AddChecked;
+
+    let cases = [
+        (2, 3, 5, 0),
+        (i64::MAX, 1, i64::MIN, 1),
+        (i64::MIN, -1, i64::MAX, 1),
+        (i64::MIN, i64::MAX, -1, 0),
+    ];
+
+    for (a, b, expected, expected_overflow) in cases {
+        let mut overflow = -1;
+        let sum = unsafe { AddChecked(a, b, &mut overflow) };
+        assert_eq!(sum, expected, "{} + {}", a, b);
+        assert_eq!(
+            overflow, expected_overflow,
+            "overflow flag for {} + {}",
+            a, b
+        );
+        assert_eq!(overflow == 1, a.checked_add(b).is_none());
+    }
+
+    // A null out-parameter is skipped.
+    assert_eq!(unsafe { AddChecked(1, 1, std::ptr::null_mut()) }, 2);
+}
+
+#[test]
+fn test_process_json() {
+    use rust_go_ffi::ffi::{FreeCString, GetLastError, ProcessJSON};
+    use std::ffi::{CStr, CString};
+
+    let input = CString::new(r#"{"a":1,"b":2}"#).unwrap();
+    let output = unsafe {
+        let ptr = ProcessJSON(input.as_ptr() as *mut _);
+        assert!(!ptr.is_null(), "Valid JSON should produce output");
+        let text = CStr::from_ptr(ptr).to_str().unwrap().to_owned();
+        FreeCString(ptr);
+        text
+    };
+    assert!(
+        output.contains(r#""sum":3"#),
+        "Unexpected output: {}",
+        output
+    );
+
+    let invalid = CString::new("not json").unwrap();
+    unsafe {
+        assert!(ProcessJSON(invalid.as_ptr() as *mut _).is_null());
+        let err = GetLastError();
+        assert!(!err.is_null(), "Invalid JSON should set the last error");
+        FreeCString(err);
+    }
+}
+
+#[test]
+fn test_log_callback_captures_go_output() {
+    use rust_go_ffi::ffi::{GoFunction, SetLogCallback};
+    use std::ffi::CStr;
+    use std::os::raw::c_char;
+    use std::sync::Mutex;
+
+    static MESSAGES: Mutex<Vec<String>> = Mutex::new(Vec::new());
+
+    extern "C" fn on_log(msg: *const c_char) {
+        // Go frees the message after we return, so copy it out.
+        let text = unsafe { CStr::from_ptr(msg) }
+            .to_string_lossy()
+            .into_owned();
+        MESSAGES.lock().unwrap().push(text);
+    }
+
+    unsafe {
+        SetLogCallback(on_log as usize);
+        GoFunction();
+        SetLogCallback(0);
+    }
+
+    let messages = MESSAGES.lock().unwrap();
+    assert!(
+        messages.iter().any(|m| m == "Hello from Go!"),
+        "Captured messages: {:?}",
+        *messages
+    );
+}
+
+#[test]
+fn test_accumulator_handles() {
+    use rust_go_ffi::ffi::{AccumulatorAdd, FreeAccumulator, NewAccumulator};
+    use rust_go_ffi::FfiError;
+
+    unsafe {
+        let a = NewAccumulator();
+        let b = NewAccumulator();
+        assert_ne!(a, b, "Handles should be distinct");
+
+        assert_eq!(AccumulatorAdd(a, 10), 10);
+        assert_eq!(AccumulatorAdd(b, 100), 100);
+        assert_eq!(AccumulatorAdd(a, 5), 15);
+        assert_eq!(AccumulatorAdd(b, -1), 99);
+
+        assert_eq!(FfiError::from_code(FreeAccumulator(a)), Some(FfiError::Ok));
+        assert_eq!(FfiError::from_code(FreeAccumulator(b)), Some(FfiError::Ok));
+        assert_eq!(
+            FfiError::from_code(FreeAccumulator(a)),
+            Some(FfiError::InvalidArg),
+            "Freeing twice should be rejected"
+        );
+        assert_eq!(AccumulatorAdd(a, 1), 0);
+    }
+}
+
+#[test]
+fn test_cancel_computation() {
+    use rust_go_ffi::ffi::{
+        CancelComputation, FreeCString, GetLastError, StartComputation, WaitComputation,
+    };
+    use rust_go_ffi::FfiError;
+
+    unsafe {
+        let finished = StartComputation(1_000);
+        assert_eq!(WaitComputation(finished), 1_000);
+
+        let handle = StartComputation(i64::MAX);
+        assert_eq!(
+            FfiError::from_code(CancelComputation(handle)),
+            Some(FfiError::Ok)
+        );
+        assert_eq!(
+            FfiError::from_code(CancelComputation(handle)),
+            Some(FfiError::Ok),
+            "Cancelling twice should be harmless"
+        );
+        assert_eq!(
+            WaitComputation(handle),
+            -1,
+            "Cancelled work should be marked incomplete"
+        );
+
+        let err = GetLastError();
+        assert!(!err.is_null());
+        FreeCString(err);
+    }
+}
+
+#[test]
+fn test_div_mod() {
+    use rust_go_ffi::ffi::DivMod;
+    use rust_go_ffi::FfiError;
+
+    let mut quot: i64 = 0;
+    let mut rem: i64 = 0;
+
+    let code = unsafe { DivMod(-7, 2, &mut quot, &mut rem) };
+    assert_eq!(FfiError::from_code(code), Some(FfiError::Ok));
+    assert_eq!((quot, rem), (-3, -1));
+
+    let (mut quot, mut rem) = (99i64, 99i64);
+    let code = unsafe { DivMod(1, 0, &mut quot, &mut rem) };
+    assert_eq!(FfiError::from_code(code), Some(FfiError::InvalidArg));
+    assert_eq!((quot, rem), (99, 99), "Outputs are untouched on error");
+}
+
+#[test]
+fn test_utf8_len_and_validation() {
+    use rust_go_ffi::ffi::{FreeCString, GetLastError, IsValidUtf8, Utf8Len};
+    use std::ffi::CString;
+
+    for (text, expected) in [
+        ("hello", 5),
+        ("é", 1),
+        ("漢", 1),
+        ("🚀", 1),
+        ("héllo漢🚀", 7),
+    ] {
+        let s = CString::new(text).unwrap();
+        unsafe {
+            assert_eq!(
+                Utf8Len(s.as_ptr() as *mut _),
+                expected,
+                "length of {:?}",
+                text
+            );
+            assert_eq!(IsValidUtf8(s.as_ptr() as *mut _), 1);
+        }
+    }
+
+    let malformed = CString::new(vec![b'a', 0xff, 0xfe]).unwrap();
+    unsafe {
+        assert_eq!(IsValidUtf8(malformed.as_ptr() as *mut _), 0);
+        assert_eq!(Utf8Len(malformed.as_ptr() as *mut _), -1);
+        let err = GetLastError();
+        assert!(!err.is_null(), "Invalid UTF-8 should set the last error");
+        FreeCString(err);
+    }
+}
+
+#[test]
+fn test_version_comparison() {
+    use rust_go_ffi::ffi::{GetVersionComponents, VersionAtLeast};
+
+    let (mut major, mut minor, mut patch) = (-1i64, -1i64, -1i64);
+    unsafe { GetVersionComponents(&mut major, &mut minor, &mut patch) };
+    assert_eq!((major, minor, patch), (0, 1, 0));
+
+    unsafe {
+        assert_eq!(VersionAtLeast(0, 1, 0), 1, "Exact match");
+        assert_eq!(VersionAtLeast(0, 0, 99), 1, "Older requirement");
+        assert_eq!(VersionAtLeast(0, 1, 1), 0, "One patch above");
+        assert_eq!(VersionAtLeast(1, 0, 0), 0, "One major above");
+    }
+}
+
+#[test]
+fn test_transform_bytes() {
+    use rust_go_ffi::ffi::{FreeBytes, TransformBytes};
+
+    let input: &[u8] = &[1, 2, 3, 0, 255];
+    let mut out_len: usize = 0;
+
+    let output = unsafe {
+        let ptr = TransformBytes(input.as_ptr() as *mut _, input.len(), &mut out_len);
+        assert!(!ptr.is_null(), "Non-empty input should produce output");
+        let bytes = std::slice::from_raw_parts(ptr, out_len).to_vec();
+        FreeBytes(ptr);
+        bytes
+    };
+    assert_eq!(output, vec![255, 0, 3, 2, 1]);
+
+    let mut empty_len: usize = 99;
+    let ptr = unsafe { TransformBytes(std::ptr::null_mut(), 0, &mut empty_len) };
+    assert!(ptr.is_null());
+    assert_eq!(empty_len, 0);
+}
+
+#[test]
+fn test_sha256_known_vectors() {
+    use rust_go_ffi::ffi::Sha256;
+
+    fn hex(bytes: &[u8]) -> String {
+        bytes.iter().map(|b| format!("{:02x}", b)).collect()
+    }
+
+    let vectors: [(&[u8], &str); 2] = [
+        (
+            b"",
+            "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+        ),
+        (
+            b"abc",
+            "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+        ),
+    ];
+
+    for (input, expected) in vectors {
+        let mut digest = [0u8; 32];
+        unsafe { Sha256(input.as_ptr() as *mut _, input.len(), digest.as_mut_ptr()) };
+        assert_eq!(hex(&digest), expected, "digest of {:?}", input);
+    }
+
+    let mut untouched = [0u8; 32];
+    unsafe { Sha256(std::ptr::null_mut(), 3, untouched.as_mut_ptr()) };
+    assert_eq!(
+        untouched, [0u8; 32],
+        "Output must be left untouched on error"
+    );
+}
+
+#[test]
+fn test_join_strings() {
+    use rust_go_ffi::ffi::{FreeCString, JoinStrings};
+    use std::ffi::{CStr, CString};
+    use std::os::raw::c_char;
+
+    let words: Vec<CString> = ["alpha", "béta", "γ"]
+        .iter()
+        .map(|s| CString::new(*s).unwrap())
+        .collect();
+    let ptrs: Vec<*const c_char> = words.iter().map(|s| s.as_ptr()).collect();
+    let sep = CString::new(", ").unwrap();
+
+    unsafe {
+        let ptr = JoinStrings(
+            ptrs.as_ptr() as *mut *mut c_char,
+            ptrs.len() as i32,
+            sep.as_ptr() as *mut _,
+        );
+        assert!(!ptr.is_null());
+        let joined = CStr::from_ptr(ptr).to_str().unwrap().to_owned();
+        FreeCString(ptr);
+        assert_eq!(joined, "alpha, béta, γ");
+
+        let empty = JoinStrings(std::ptr::null_mut(), 0, sep.as_ptr() as *mut _);
+        assert!(!empty.is_null());
+        assert_eq!(CStr::from_ptr(empty).to_bytes(), b"");
+        FreeCString(empty);
+    }
+}
+
+#[test]
+fn test_seeded_random_repeats() {
+    use rust_go_ffi::ffi::{NextRandom, SeedRandom};
+
+    unsafe {
+        SeedRandom(2024);
+        let first: Vec<i64> = (0..8).map(|_| NextRandom()).collect();
+        SeedRandom(2024);
+        let second: Vec<i64> = (0..8).map(|_| NextRandom()).collect();
+
+        assert_eq!(first, second, "Same seed should give the same sequence");
+        assert!(first.iter().all(|&n| n >= 0));
+    }
+}
+
+#[test]
+fn test_make_range() {
+    use rust_go_ffi::ffi::{FreeInt64Array, MakeRange};
+
+    unsafe {
+        let mut len = 0usize;
+        let ptr = MakeRange(-3, 4, &mut len);
+        assert!(!ptr.is_null());
+        let values = std::slice::from_raw_parts(ptr, len).to_vec();
+        FreeInt64Array(ptr);
+        assert_eq!(values, (-3..4).collect::<Vec<i64>>());
+
+        let mut len = 99usize;
+        let ptr = MakeRange(10, 10, &mut len);
+        assert!(ptr.is_null(), "An empty range should return null");
+        assert_eq!(len, 0);
+
+        let mut len = 0usize;
+        let ptr = MakeRange(0, 1_000_000, &mut len);
+        assert!(!ptr.is_null());
+        let values = std::slice::from_raw_parts(ptr, len);
+        assert_eq!(values.len(), 1_000_000);
+        assert!(values.iter().enumerate().all(|(i, &v)| v == i as i64));
+        FreeInt64Array(ptr);
+    }
+}
+
+#[test]
+fn test_maybe_double() {
+    use rust_go_ffi::ffi::{option_from_c, option_to_c, MaybeDouble};
+
+    let maybe_double = |input: Option<i64>| unsafe {
+        let (value, has_value) = option_to_c(input);
+        let mut out_has_value = -1;
+        let doubled = MaybeDouble(value, has_value, &mut out_has_value);
+        option_from_c(doubled, out_has_value)
+    };
+
+    assert_eq!(maybe_double(Some(21)), Some(42));
+    assert_eq!(maybe_double(Some(-4)), Some(-8));
+    assert_eq!(maybe_double(Some(0)), Some(0), "Zero is a value, not None");
+    assert_eq!(maybe_double(None), None);
+}
+
+#[test]
+fn test_mem_stats_after_gc() {
+    use rust_go_ffi::ffi::{ForceGC, FreeCString, GetMemStatsJSON};
+    use std::ffi::CStr;
+
+    fn num_gc() -> u64 {
+        unsafe {
+            let ptr = GetMemStatsJSON();
+            assert!(!ptr.is_null());
+            let json = CStr::from_ptr(ptr).to_str().unwrap().to_owned();
+            FreeCString(ptr);
+
+            let start = json.find("\"NumGC\":").expect("NumGC field") + "\"NumGC\":".len();
+            let digits: String = json[start..]
+                .chars()
+                .take_while(|c| c.is_ascii_digit())
+                .collect();
+            digits.parse().unwrap()
+        }
+    }
+
+    let before = num_gc();
+    unsafe { ForceGC() };
+    assert!(num_gc() > before, "ForceGC should run a collection");
+}
+
+#[test]
+fn test_add_numbers_batch() {
+    use rust_go_ffi::ffi::AddNumbersBatch;
+
+    for n in [0usize, 1, 100_000] {
+        let mut a: Vec<i64> = (0..n as i64).collect();
+        let mut b: Vec<i64> = (0..n as i64).map(|i| 3 - i * 2).collect();
+        let mut out = vec![0i64; n];
+
+        unsafe { AddNumbersBatch(a.as_mut_ptr(), b.as_mut_ptr(), out.as_mut_ptr(), n) };
+
+        let expected: Vec<i64> = a.iter().zip(&b).map(|(x, y)| x + y).collect();
+        assert_eq!(out, expected, "n = {n}");
+    }
+}
+
+#[test]
+fn test_utf16_to_utf8() {
+    use rust_go_ffi::ffi::{FreeCString, Utf16ToUtf8};
+    use std::ffi::CStr;
+
+    let decode = |units: &[u16]| unsafe {
+        let ptr = Utf16ToUtf8(units.as_ptr() as *mut u16, units.len());
+        assert!(!ptr.is_null());
+        let text = CStr::from_ptr(ptr).to_str().unwrap().to_owned();
+        FreeCString(ptr);
+        text
+    };
+
+    let wide: Vec<u16> = "héllo 漢字 🚀".encode_utf16().collect();
+    assert_eq!(decode(&wide), "héllo 漢字 🚀");
+    assert_eq!(decode(&[0xD83D, 0xDE80]), "🚀");
+    assert_eq!(decode(&[u16::from(b'a'), 0xD83D]), "a\u{FFFD}");
+    assert_eq!(decode(&[]), "");
+}
+
+#[test]
+fn test_divide_safe() {
+    use rust_go_ffi::ffi::{DivideSafe, FreeResult};
+    use rust_go_ffi::FfiError;
+    use std::ffi::CStr;
+
+    unsafe {
+        let ok = DivideSafe(17, 5);
+        assert!(ok.message.is_null(), "Success should carry no message");
+        assert_eq!(ok.into_result(), Ok(3));
+
+        let err = DivideSafe(1, 0);
+        assert_eq!(FfiError::from_code(err.code), Some(FfiError::InvalidArg));
+        assert!(!err.message.is_null());
+        assert_eq!(
+            CStr::from_ptr(err.message).to_str().unwrap(),
+            "DivideSafe: division by zero"
+        );
+        FreeResult(err);
+
+        assert_eq!(
+            DivideSafe(1, 0).into_result(),
+            Err((
+                FfiError::InvalidArg,
+                "DivideSafe: division by zero".to_string()
+            ))
+        );
+    }
+}
+
+#[test]
+fn test_stream_range_collects_values() {
+    use rust_go_ffi::ffi::StreamRange;
+    use std::os::raw::c_int;
+    use std::sync::Mutex;
+
+    static VALUES: Mutex<Vec<i64>> = Mutex::new(Vec::new());
+
+    extern "C" fn collect(value: i64) -> c_int {
+        VALUES.lock().unwrap().push(value);
+        0
+    }
+
+    unsafe {
+        assert_eq!(StreamRange(-3, 7, collect as usize), 10);
+        assert_eq!(StreamRange(4, 4, collect as usize), 0);
+        assert_eq!(StreamRange(0, 3, 0), -1, "A null callback should fail");
+    }
+    assert_eq!(*VALUES.lock().unwrap(), (-3..7).collect::<Vec<i64>>());
+}
+
+#[test]
+fn test_stream_range_stops_early() {
+    use rust_go_ffi::ffi::StreamRange;
+    use std::os::raw::c_int;
+    use std::sync::Mutex;
+
+    static VALUES: Mutex<Vec<i64>> = Mutex::new(Vec::new());
+
+    extern "C" fn take_five(value: i64) -> c_int {
+        let mut values = VALUES.lock().unwrap();
+        values.push(value);
+        (values.len() == 5) as c_int
+    }
+
+    let delivered = unsafe { StreamRange(100, 1_000_000, take_five as usize) };
+    assert_eq!(delivered, 5);
+    assert_eq!(*VALUES.lock().unwrap(), vec![100, 101, 102, 103, 104]);
+}
+
+#[test]
+fn test_list_exports() {
+    use rust_go_ffi::ffi::{FreeCString, ListExports};
+    use std::ffi::CStr;
+
+    let json = unsafe {
+        let ptr = ListExports();
+        assert!(!ptr.is_null());
+        let json = CStr::from_ptr(ptr).to_str().unwrap().to_owned();
+        FreeCString(ptr);
+        json
+    };
+
+    assert!(json.starts_with('[') && json.ends_with(']'), "{json}");
+    assert!(json.contains(
+        r#"{"name":"AddNumbers","params":["long long","long long"],"result":"long long"}"#
+    ));
+    assert!(json.contains(r#"{"name":"GoFunction","params":[],"result":"void"}"#));
+}
+
+#[test]
+fn test_time_round_trip() {
+    use rust_go_ffi::ffi::{FormatUnixNanos, FreeCString, GetLastError, ParseRFC3339};
+    use std::ffi::{CStr, CString};
+
+    let format = |ns: i64| unsafe {
+        let ptr = FormatUnixNanos(ns);
+        assert!(!ptr.is_null());
+        let text = CStr::from_ptr(ptr).to_str().unwrap().to_owned();
+        FreeCString(ptr);
+        text
+    };
+    let parse = |text: &str| {
+        let c = CString::new(text).unwrap();
+        unsafe { ParseRFC3339(c.as_ptr() as *mut _) }
+    };
+
+    let instant = 1_709_294_400_123_456_789;
+    assert_eq!(format(instant), "2024-03-01T12:00:00.123456789Z");
+    assert_eq!(parse("2024-03-01T12:00:00.123456789Z"), instant);
+
+    assert_eq!(format(-86_400_000_000_000), "1969-12-31T00:00:00Z");
+    assert_eq!(parse("1969-12-31T00:00:00Z"), -86_400_000_000_000);
+
+    unsafe {
+        assert_eq!(parse("not a time"), -1);
+        let err = GetLastError();
+        assert!(!err.is_null(), "A parse failure should set the last error");
+        FreeCString(err);
+
+        assert_eq!(ParseRFC3339(std::ptr::null_mut()), -1);
+    }
+}
+
+#[test]
+fn test_parallel_square() {
+    use rust_go_ffi::ffi::ParallelSquare;
+
+    let mut input: Vec<i64> = (-50_000..50_000).collect();
+    let mut output = vec![0i64; input.len()];
+
+    for workers in [0, 1, 4, 64] {
+        output.iter_mut().for_each(|v| *v = 0);
+        unsafe {
+            ParallelSquare(
+                input.as_mut_ptr(),
+                output.as_mut_ptr(),
+                input.len(),
+                workers,
+            )
+        };
+        assert!(
+            input.iter().zip(&output).all(|(x, y)| x * x == *y),
+            "workers = {workers}"
+        );
+    }
+}
+
+#[test]
+fn test_config_set_get() {
+    use rust_go_ffi::ffi::{FreeCString, GetConfig, SetConfig};
+    use rust_go_ffi::FfiError;
+    use std::ffi::{CStr, CString};
+
+    let get = |key: &str| unsafe {
+        let key = CString::new(key).unwrap();
+        let ptr = GetConfig(key.as_ptr() as *mut _);
+        if ptr.is_null() {
+            return None;
+        }
+        let value = CStr::from_ptr(ptr).to_str().unwrap().to_owned();
+        FreeCString(ptr);
+        Some(value)
+    };
+
+    assert_eq!(get("it.feature"), None);
+    unsafe {
+        // Go copies both strings, so they can be dropped right away.
+        let key = CString::new("it.feature").unwrap();
+        let value = CString::new("enabled").unwrap();
+        let code = SetConfig(key.as_ptr() as *mut _, value.as_ptr() as *mut _);
+        assert_eq!(FfiError::from_code(code), Some(FfiError::Ok));
+    }
+    assert_eq!(get("it.feature").as_deref(), Some("enabled"));
+
+    unsafe {
+        let key = CString::new("it.feature").unwrap();
+        SetConfig(key.as_ptr() as *mut _, std::ptr::null_mut());
+    }
+    assert_eq!(get("it.feature"), None);
+}
+
+#[test]
+fn test_ping_and_echo() {
+    use rust_go_ffi::ffi::{Echo, FreeCString, Ping};
+    use std::ffi::{CStr, CString};
+
+    unsafe {
+        for token in [0, 7, i64::MAX, i64::MIN] {
+            assert_eq!(Ping(token), token);
+        }
+
+        for text in ["", "are you there?", "漢字 🚀"] {
+            let input = CString::new(text).unwrap();
+            let ptr = Echo(input.as_ptr() as *mut _);
+            assert!(!ptr.is_null());
+            assert_ne!(ptr as *const _, input.as_ptr(), "Echo must return a copy");
+            drop(input);
+            assert_eq!(CStr::from_ptr(ptr).to_str().unwrap(), text);
+            FreeCString(ptr);
+        }
+
+        assert!(Echo(std::ptr::null_mut()).is_null());
+    }
+}
+
+#[test]
+fn test_base64_round_trip() {
+    use rust_go_ffi::ffi::{Base64Decode, Base64Encode, FreeBytes, FreeCString};
+    use std::ffi::{CStr, CString};
+
+    let mut data: Vec<u8> = (0..=255).collect();
+
+    unsafe {
+        let encoded = Base64Encode(data.as_mut_ptr(), data.len());
+        assert!(!encoded.is_null());
+        let text = CStr::from_ptr(encoded).to_str().unwrap().to_owned();
+        assert!(text.starts_with("AAECAwQF"), "{text}");
+
+        let mut len = 0usize;
+        let decoded = Base64Decode(encoded, &mut len);
+        FreeCString(encoded);
+        assert_eq!(std::slice::from_raw_parts(decoded, len), &data[..]);
+        FreeBytes(decoded);
+
+        let invalid = CString::new("***").unwrap();
+        let mut len = 99usize;
+        assert!(Base64Decode(invalid.as_ptr() as *mut _, &mut len).is_null());
+        assert_eq!(len, 0);
+    }
+}
+
+#[test]
+fn test_parse_int_result() {
+    use rust_go_ffi::ffi::ParseInt;
+    use rust_go_ffi::FfiError;
+    use std::ffi::CString;
+
+    let parse = |text: &str| {
+        let c = CString::new(text).unwrap();
+        unsafe { ParseInt(c.as_ptr() as *mut _).into_result() }
+    };
+
+    assert_eq!(parse("123"), Ok(123));
+    assert_eq!(parse("-77"), Ok(-77));
+    assert_eq!(
+        parse("abc"),
+        Err((
+            FfiError::InvalidArg,
+            r#"strconv.ParseInt: parsing "abc": invalid syntax"#.to_string()
+        ))
+    );
+    assert_eq!(
+        parse(""),
+        Err((
+            FfiError::InvalidArg,
+            r#"strconv.ParseInt: parsing "": invalid syntax"#.to_string()
+        ))
+    );
+}
+
+#[test]
+fn test_require_version() {
+    use rust_go_ffi::ffi::RequireVersion;
+    use rust_go_ffi::FfiError;
+
+    unsafe {
+        assert_eq!(
+            FfiError::from_code(RequireVersion(0, 1, 0)),
+            Some(FfiError::Ok),
+            "The exact current version should be accepted"
+        );
+        assert_eq!(
+            FfiError::from_code(RequireVersion(0, 1, 1)),
+            Some(FfiError::VersionMismatch)
+        );
+        assert_eq!(
+            FfiError::from_code(RequireVersion(1, 0, 0)),
+            Some(FfiError::VersionMismatch)
+        );
+    }
+}
+
+#[test]
+fn test_noop_and_busy_work() {
+    use rust_go_ffi::ffi::{BusyWork, NoOp};
+
+    unsafe {
+        NoOp();
+        assert_eq!(BusyWork(0), 0);
+        assert_eq!(BusyWork(-3), 0);
+        assert_eq!(BusyWork(1000), 499_500);
+    }
+}
+
+#[test]
+fn test_compare_int_sorts() {
+    use rust_go_ffi::ffi::{c_ordering, CompareInt};
+
+    let mut values = vec![3, i64::MIN, 0, -7, i64::MAX, 3, 42];
+    values.sort_by(|a, b| c_ordering(unsafe { CompareInt(*a, *b) }));
+    assert_eq!(values, vec![i64::MIN, -7, 0, 3, 3, 42, i64::MAX]);
+
+    unsafe {
+        assert_eq!(CompareInt(i64::MIN, i64::MAX), -1);
+        assert_eq!(CompareInt(i64::MAX, i64::MIN), 1);
+        assert_eq!(CompareInt(5, 5), 0);
+    }
+}
@@ -0,0 +1,133 @@
+//! Library init/shutdown ordering, call metrics, config-dependent limits and
+//! state snapshots.
+//! These tests change global Go state, so they live in their own test binary
+//! instead of `integration_test.rs`.
+
+use rust_go_ffi::ffi::{
+    AccumulatorAdd, AddNumbers, ExportState, FreeAccumulator, FreeCString, GetConfig,
+    GetMetricsJSON, ImportState, IsEven, LibInit, LibShutdown, NewAccumulator, ResetMetrics,
+    SetConfig, SumArray,
+};
+use rust_go_ffi::FfiError;
+use std::ffi::{CStr, CString};
+use std::sync::Mutex;
+
+// Tests in this file share the library's global state; run them one at a time.
+static LOCK: Mutex<()> = Mutex::new(());
+
+#[test]
+fn test_init_use_shutdown() {
+    let _guard = LOCK.lock().unwrap();
+
+    unsafe {
+        assert_eq!(FfiError::from_code(LibInit()), Some(FfiError::Ok));
+        assert_eq!(FfiError::from_code(LibInit()), Some(FfiError::Ok));
+
+        let acc = NewAccumulator();
+        assert_eq!(AccumulatorAdd(acc, 4), 4);
+
+        LibShutdown();
+        LibShutdown();
+
+        assert_eq!(
+            FfiError::from_code(FreeAccumulator(acc)),
+            Some(FfiError::InvalidArg),
+            "Handles should not survive shutdown"
+        );
+    }
+}
+
+#[test]
+fn test_use_before_init() {
+    let _guard = LOCK.lock().unwrap();
+
+    unsafe {
+        LibShutdown();
+        assert_eq!(AddNumbers(2, 3), 5, "Exports initialize the library lazily");
+        LibShutdown();
+    }
+}
+
+#[test]
+fn test_metrics_count_calls() {
+    let _guard = LOCK.lock().unwrap();
+
+    unsafe {
+        ResetMetrics();
+        for i in 0..25 {
+            AddNumbers(i, 1);
+        }
+        IsEven(4);
+
+        let ptr = GetMetricsJSON();
+        assert!(!ptr.is_null());
+        let json = CStr::from_ptr(ptr).to_str().unwrap().to_owned();
+        FreeCString(ptr);
+
+        assert!(json.contains(r#""AddNumbers":{"calls":25,"#), "{json}");
+        assert!(json.contains(r#""IsEven":{"calls":1,"#), "{json}");
+
+        ResetMetrics();
+        let ptr = GetMetricsJSON();
+        let json = CStr::from_ptr(ptr).to_str().unwrap().to_owned();
+        FreeCString(ptr);
+        assert_eq!(json, "{}");
+    }
+}
+
+#[test]
+fn test_sum_array_respects_max_array_len() {
+    let _guard = LOCK.lock().unwrap();
+
+    let key = CString::new("max_array_len").unwrap();
+    let limit = CString::new("3").unwrap();
+    let mut values = vec![5i64, 6, 7];
+
+    unsafe {
+        // Far past the default cap of 1 << 31: rejected before any read.
+        assert_eq!(SumArray(values.as_mut_ptr(), 1 << 40), 0);
+
+        SetConfig(key.as_ptr() as *mut _, limit.as_ptr() as *mut _);
+        assert_eq!(SumArray(values.as_mut_ptr(), 3), 18);
+        assert_eq!(SumArray(values.as_mut_ptr(), 4), 0, "Past the cap");
+
+        SetConfig(key.as_ptr() as *mut _, std::ptr::null_mut());
+    }
+}
+
+#[test]
+fn test_state_survives_export_and_import() {
+    let _guard = LOCK.lock().unwrap();
+
+    unsafe {
+        let acc = NewAccumulator();
+        AccumulatorAdd(acc, 123);
+        let key = CString::new("reload.flag").unwrap();
+        let value = CString::new("yes").unwrap();
+        SetConfig(key.as_ptr() as *mut _, value.as_ptr() as *mut _);
+
+        let ptr = ExportState();
+        assert!(!ptr.is_null());
+        let snapshot = CStr::from_ptr(ptr).to_owned();
+        FreeCString(ptr);
+
+        // Stands in for unloading and reloading the library.
+        LibShutdown();
+
+        let code = ImportState(snapshot.as_ptr() as *mut _);
+        assert_eq!(FfiError::from_code(code), Some(FfiError::Ok));
+        assert_eq!(AccumulatorAdd(acc, 0), 123, "Handles stay valid");
+        let restored = GetConfig(key.as_ptr() as *mut _);
+        assert!(!restored.is_null());
+        assert_eq!(CStr::from_ptr(restored).to_str().unwrap(), "yes");
+        FreeCString(restored);
+
+        let partial = CString::new(r#"{"version":2,"accumulators":{}}"#).unwrap();
+        assert_eq!(
+            FfiError::from_code(ImportState(partial.as_ptr() as *mut _)),
+            Some(FfiError::PartialState)
+        );
+
+        LibShutdown();
+    }
+}